@@ -21,14 +21,14 @@ type Audio struct {
 	APIs           []APIFramework      `json:"api,omitempty"`           // -
 	CompanionTypes []CompanionType     `json:"companiontype,omitempty"` // -
 	Ext            json.RawMessage     `json:"ext,omitempty"`           // -
-	MinDuration    int16               `json:"minduration,omitempty"`   // Minimum video ad duration in seconds
-	MaxDuration    int16               `json:"maxduration,omitempty"`   // Maximum video ad duration in seconds
+	MinDuration    int                 `json:"minduration,omitempty"`   // Minimum video ad duration in seconds
+	MaxDuration    int                 `json:"maxduration,omitempty"`   // Maximum video ad duration in seconds
 	StartDelay     StartDelay          `json:"startdelay"`              // Indicates the start delay in seconds
-	Sequence       int16               `json:"sequence,omitempty"`      // Default: 1
-	MaxExtended    int16               `json:"maxextended,omitempty"`   // Maximum extended video ad duration
-	MinBitrate     int16               `json:"minbitrate,omitempty"`    // Minimum bit rate in Kbps
-	MaxBitrate     int16               `json:"maxbitrate,omitempty"`    // Maximum bit rate in Kbps
-	MaxSequence    int16               `json:"maxseq,omitempty"`        // The maximumnumber of ads that canbe played in an ad pod.
+	Sequence       int                 `json:"sequence,omitempty"`      // Default: 1
+	MaxExtended    int                 `json:"maxextended,omitempty"`   // Maximum extended video ad duration
+	MinBitrate     int                 `json:"minbitrate,omitempty"`    // Minimum bit rate in Kbps
+	MaxBitrate     int                 `json:"maxbitrate,omitempty"`    // Maximum bit rate in Kbps
+	MaxSequence    int                 `json:"maxseq,omitempty"`        // The maximumnumber of ads that canbe played in an ad pod.
 	Feed           FeedType            `json:"feed,omitempty"`          // Type of audio feed.
 	Stitched       int8                `json:"stitched,omitempty"`      // Indicates if the ad is stitched with audio content or delivered independently
 	VolumeNorm     VolumeNorm          `json:"nvol,omitempty"`          // Volume normalization mode.
@@ -17,13 +17,144 @@ type Banner struct {
 	APIs         []APIFramework      `json:"api,omitempty"`      // List of supported API frameworks
 	Ext          json.RawMessage     `json:"ext,omitempty"`      // -
 	ID           string              `json:"id,omitempty"`       // A unique identifier
-	Width        int16               `json:"w,omitempty"`        // Width
-	Height       int16               `json:"h,omitempty"`        // Height
-	WidthMax     int16               `json:"wmax,omitempty"`     // Width maximum DEPRECATED
-	HeightMax    int16               `json:"hmax,omitempty"`     // Height maximum DEPRECATED
-	WidthMin     int16               `json:"wmin,omitempty"`     // Width minimum DEPRECATED
-	HeightMin    int16               `json:"hmin,omitempty"`     // Height minimum DEPRECATED
+	Width        int                 `json:"w,omitempty"`        // Width
+	Height       int                 `json:"h,omitempty"`        // Height
+	WidthMax     int                 `json:"wmax,omitempty"`     // Width maximum DEPRECATED
+	HeightMax    int                 `json:"hmax,omitempty"`     // Height maximum DEPRECATED
+	WidthMin     int                 `json:"wmin,omitempty"`     // Width minimum DEPRECATED
+	HeightMin    int                 `json:"hmin,omitempty"`     // Height minimum DEPRECATED
 	Position     AdPosition          `json:"pos,omitempty"`      // Ad Position
 	TopFrame     int8                `json:"topframe,omitempty"` // Default: 0 ("1": Delivered in top frame, "0": Elsewhere)
 	VCM          int8                `json:"vcm,omitempty"`      // Represents the relationship with video. 0 = concurrent, 1 = end-card
 }
+
+// Accepts returns true if a creative of w x h DIPS fits the banner. Formats
+// take precedence over the banner size; if no formats are specified, the
+// exact size applies. The deprecated size ranges are accepted in either case.
+// A banner without any size information accepts no creative.
+func (b *Banner) Accepts(w, h int) bool {
+	if w <= 0 || h <= 0 {
+		return false
+	}
+	return b.slot(&Format{Width: w, Height: h}) >= 0
+}
+
+// BestFormat returns the index of the creative size which fits the banner
+// best, or -1 if none fits. Creatives may be fixed sizes or, for flex ads,
+// ratios with an optional minimum width. Creatives matching the preferred
+// banner size win, followed by those matching the earliest format, fixed
+// sizes and finally larger sizes.
+func (b *Banner) BestFormat(creatives []Format) int {
+	best, bestSlot, bestPreferred := -1, 0, false
+	for i := range creatives {
+		c := &creatives[i]
+		slot := b.slot(c)
+		if slot < 0 {
+			continue
+		}
+
+		preferred := b.Width != 0 && c.Width == b.Width && c.Height == b.Height
+		if best >= 0 {
+			cur := &creatives[best]
+			switch {
+			case preferred != bestPreferred:
+				if !preferred {
+					continue
+				}
+			case slot != bestSlot:
+				if slot > bestSlot {
+					continue
+				}
+			case c.IsFlex() != cur.IsFlex():
+				if c.IsFlex() {
+					continue
+				}
+			case c.Width*c.Height <= cur.Width*cur.Height:
+				continue
+			}
+		}
+		best, bestSlot, bestPreferred = i, slot, preferred
+	}
+	return best
+}
+
+// NormalizeFormats converts the deprecated size fields into format entries if
+// no formats are specified. Size ranges are represented by their minimum and
+// maximum sizes, the range fields are retained so that Accepts and
+// BestFormat still accept all sizes within. Returns true if formats were
+// added.
+func (b *Banner) NormalizeFormats() bool {
+	if len(b.Formats) != 0 {
+		return false
+	}
+
+	add := func(w, h int) {
+		for _, f := range b.Formats {
+			if f.Width == w && f.Height == h {
+				return
+			}
+		}
+		b.Formats = append(b.Formats, Format{Width: w, Height: h})
+	}
+	if b.Width > 0 && b.Height > 0 {
+		add(b.Width, b.Height)
+	}
+	if b.WidthMax > 0 && b.HeightMax > 0 {
+		add(b.WidthMax, b.HeightMax)
+	}
+	if b.WidthMin > 0 && b.HeightMin > 0 {
+		add(b.WidthMin, b.HeightMin)
+	}
+	return len(b.Formats) != 0
+}
+
+// slot returns the rank of the banner size accepting creative c, or -1. Size
+// ranges rank after formats and the exact size.
+func (b *Banner) slot(c *Format) int {
+	rank := len(b.Formats)
+	if rank != 0 {
+		for i := range b.Formats {
+			if b.Formats[i].fits(c) {
+				return i
+			}
+		}
+	} else if b.Width > 0 && b.Height > 0 {
+		if exact := (Format{Width: b.Width, Height: b.Height}); exact.fits(c) {
+			return 0
+		}
+		rank = 1
+	}
+
+	if b.hasSizeRange() && c.Width > 0 && c.Height > 0 &&
+		inRange(c.Width, b.WidthMin, b.WidthMax) && inRange(c.Height, b.HeightMin, b.HeightMax) {
+		return rank
+	}
+	return -1
+}
+
+func (b *Banner) hasSizeRange() bool {
+	return b.WidthMin > 0 || b.WidthMax > 0 || b.HeightMin > 0 || b.HeightMax > 0
+}
+
+func inRange(v, lo, hi int) bool {
+	return v >= lo && (hi <= 0 || v <= hi)
+}
+
+// fits returns true if creative c can be displayed in f.
+func (f *Format) fits(c *Format) bool {
+	switch {
+	case c.Width > 0 && c.Height > 0:
+		if f.Width > 0 && f.Height > 0 {
+			return c.Width == f.Width && c.Height == f.Height
+		}
+		return f.IsFlex() && c.Width >= f.WidthMin &&
+			c.Width*f.HeightRatio == c.Height*f.WidthRatio
+	case c.IsFlex():
+		if f.Width > 0 && f.Height > 0 {
+			return f.Width >= c.WidthMin &&
+				f.Width*c.HeightRatio == f.Height*c.WidthRatio
+		}
+		return f.IsFlex() && f.WidthRatio*c.HeightRatio == f.HeightRatio*c.WidthRatio
+	}
+	return false
+}
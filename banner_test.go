@@ -26,3 +26,121 @@ func TestBanner(t *testing.T) {
 		t.Errorf("expected %+v, got %+v", exp, got)
 	}
 }
+
+func TestBanner_flex(t *testing.T) {
+	var subject *Banner
+	if err := fixture("banner.flex", &subject); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := []Format{
+		{Width: 300, Height: 250},
+		{Width: 320, Height: 50},
+		{WidthRatio: 16, HeightRatio: 9, WidthMin: 320},
+		{WidthRatio: 1, HeightRatio: 1},
+	}
+	if got := subject.Formats; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+}
+
+func TestBanner_Accepts(t *testing.T) {
+	var flex *Banner
+	if err := fixture("banner.flex", &flex); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, tc := range []struct {
+		subject *Banner
+		w, h    int
+		exp     bool
+	}{
+		{flex, 300, 250, true},
+		{flex, 320, 50, true},
+		{flex, 728, 90, false},
+		{flex, 640, 360, true},
+		{flex, 160, 90, false}, // below wmin
+		{flex, 250, 250, true},
+		{flex, 0, 0, false},
+
+		{&Banner{Width: 728, Height: 90}, 728, 90, true},
+		{&Banner{Width: 728, Height: 90}, 300, 250, false},
+		{&Banner{Width: 728, Height: 90, Formats: []Format{{Width: 300, Height: 250}}}, 728, 90, false},
+		{&Banner{Width: 728, Height: 90, WidthMin: 300, WidthMax: 970, HeightMax: 250}, 970, 250, true},
+		{&Banner{Width: 728, Height: 90, WidthMin: 300, WidthMax: 970, HeightMax: 250}, 970, 251, false},
+		{&Banner{WidthMin: 300}, 300, 600, true},
+		{&Banner{WidthMin: 300}, 160, 600, false},
+		{&Banner{}, 300, 250, false},
+	} {
+		if got := tc.subject.Accepts(tc.w, tc.h); tc.exp != got {
+			t.Errorf("expected %v for %dx%d in %+v, got %v", tc.exp, tc.w, tc.h, tc.subject, got)
+		}
+	}
+}
+
+func TestBanner_BestFormat(t *testing.T) {
+	var subject *Banner
+	if err := fixture("banner.flex", &subject); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, tc := range []struct {
+		creatives []Format
+		exp       int
+	}{
+		{nil, -1},
+		{[]Format{{Width: 728, Height: 90}}, -1},
+		{[]Format{{Width: 728, Height: 90}, {Width: 640, Height: 360}}, 1},
+		{[]Format{{Width: 640, Height: 360}, {Width: 300, Height: 250}}, 1},         // earlier format
+		{[]Format{{Width: 300, Height: 250}, {Width: 320, Height: 50}}, 1},          // preferred size
+		{[]Format{{Width: 640, Height: 360}, {Width: 1280, Height: 720}}, 1},        // larger
+		{[]Format{{WidthRatio: 32, HeightRatio: 18}, {Width: 640, Height: 360}}, 1}, // fixed size
+		{[]Format{{WidthRatio: 32, HeightRatio: 18}, {WidthRatio: 2, HeightRatio: 2}}, 0},
+		{[]Format{{WidthRatio: 6, HeightRatio: 5}}, 0}, // 300x250
+	} {
+		if got := subject.BestFormat(tc.creatives); tc.exp != got {
+			t.Errorf("expected %v for %+v, got %v", tc.exp, tc.creatives, got)
+		}
+	}
+}
+
+func TestBanner_NormalizeFormats(t *testing.T) {
+	subject := &Banner{Width: 728, Height: 90, WidthMin: 300, HeightMin: 50, WidthMax: 728, HeightMax: 90, Position: AdPositionAboveFold}
+	if !subject.NormalizeFormats() {
+		t.Errorf("expected formats to be added")
+	}
+
+	exp := &Banner{
+		Width:     728,
+		Height:    90,
+		WidthMin:  300,
+		HeightMin: 50,
+		WidthMax:  728,
+		HeightMax: 90,
+		Position:  AdPositionAboveFold,
+		Formats:   []Format{{Width: 728, Height: 90}, {Width: 300, Height: 50}},
+	}
+	if got := subject; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if !subject.Accepts(468, 60) {
+		t.Errorf("expected sizes within the range to be accepted")
+	}
+	if subject.NormalizeFormats() {
+		t.Errorf("expected no formats to be added")
+	}
+
+	subject = &Banner{WidthMin: 300, HeightMin: 50, WidthMax: 970, HeightMax: 250}
+	subject.NormalizeFormats()
+	if !subject.Accepts(728, 90) {
+		t.Errorf("expected normalization to retain the size range")
+	}
+
+	subject = &Banner{WidthMax: 970}
+	if subject.NormalizeFormats() {
+		t.Errorf("expected no formats to be added")
+	}
+	if exp, got := 970, subject.WidthMax; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
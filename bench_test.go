@@ -41,3 +41,95 @@ func BenchmarkBidRequest_Marshal(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkBidRequest_Clone(b *testing.B) {
+	data, err := os.ReadFile(filepath.Join("testdata", "breq.video.json"))
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+
+	var req *BidRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		b.Fatal(err.Error())
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = req.Clone()
+	}
+}
+
+func BenchmarkMask_Apply(b *testing.B) {
+	data, err := os.ReadFile(filepath.Join("testdata", "breq.video.json"))
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+
+	var req *BidRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		b.Fatal(err.Error())
+	}
+
+	mask, err := NewMask(MaskDeny, "user.data", "device.ifa", "device.geo.lat", "device.geo.lon", "imp.*.pmp")
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = mask.Apply(req)
+	}
+}
+
+// BenchmarkMask_editJSON is the baseline for BenchmarkMask_Apply: it removes
+// the same members by marshalling the request, editing the generic document
+// and unmarshalling it again.
+func BenchmarkMask_editJSON(b *testing.B) {
+	data, err := os.ReadFile(filepath.Join("testdata", "breq.video.json"))
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+
+	var req *BidRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		b.Fatal(err.Error())
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data, err := json.Marshal(req)
+		if err != nil {
+			b.Fatal(err.Error())
+		}
+
+		var doc map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			b.Fatal(err.Error())
+		}
+		if user, ok := doc["user"].(map[string]interface{}); ok {
+			delete(user, "data")
+		}
+		if device, ok := doc["device"].(map[string]interface{}); ok {
+			delete(device, "ifa")
+			if geo, ok := device["geo"].(map[string]interface{}); ok {
+				delete(geo, "lat")
+				delete(geo, "lon")
+			}
+		}
+		if imps, ok := doc["imp"].([]interface{}); ok {
+			for _, imp := range imps {
+				if imp, ok := imp.(map[string]interface{}); ok {
+					delete(imp, "pmp")
+				}
+			}
+		}
+
+		if data, err = json.Marshal(doc); err != nil {
+			b.Fatal(err.Error())
+		}
+		var masked *BidRequest
+		if err := json.Unmarshal(data, &masked); err != nil {
+			b.Fatal(err.Error())
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package openrtb
 
 import (
+	"bytes"
 	"errors"
 
 	"github.com/goccy/go-json"
@@ -50,10 +51,26 @@ type Bid struct {
 	API              APIFramework        `json:"api,omitempty"`            // API required by the markup if applicable, NOTE: for ORTB ver <= 2.5 APIFramework supported is 1 to 6.
 	Protocol         Protocol            `json:"protocol,omitempty"`       // Video response protocol of the markup if applicable
 	MediaRating      IQGRating           `json:"qagmediarating,omitempty"` // Creative media rating per IQG guidelines.
-	APIS             APIFramework        `json:"apis,omitempty"`           // APIS required by the markup if applicable.
+	APIS             []APIFramework      `json:"apis,omitempty"`           // APIS required by the markup if applicable.
 	MarkupType       MarkupType          `json:"mtype,omitempty"`          // Creative markup so that it can properly be associated.
 	SlotInPod        SlotPositionInPod   `json:"slotinpod,omitempty"`      // Indicates that the bid response is only eligible for a specific position.
 	CategoryTaxonomy CategoryTaxonomy    `json:"cattax,omitempty"`         // Defines the taxonomy in use.
+	DSA              *DSA                `json:"dsa,omitempty"`            // Digital Services Act transparency information. Passed as bid.ext.dsa before 2.6.
+}
+
+// DSA object carries the transparency information of an ad required by the EU Digital Services
+// Act, as defined by the IAB Tech Lab DSA Transparency extension.
+type DSA struct {
+	Transparency []DSATransparency `json:"transparency,omitempty"` // Entities which applied user parameters and the parameters applied.
+	Behalf       string            `json:"behalf,omitempty"`       // Advertiser brand on whose behalf the ad is shown.
+	Paid         string            `json:"paid,omitempty"`         // Advertiser or agent who paid for the ad.
+	AdRender     int8              `json:"adrender,omitempty"`     // Indicates if the buyer will render the DSA information, where 0 = no, 1 = yes.
+}
+
+// DSATransparency identifies an entity which applied user parameters to target an ad.
+type DSATransparency struct {
+	Domain string `json:"domain,omitempty"`    // Domain of the entity that applied user parameters.
+	Params []int  `json:"dsaparams,omitempty"` // User parameters used for the ad, where 1 = profiling, 2 = basic advertising, 3 = precise geolocation.
 }
 
 // Validate required attributes
@@ -63,6 +80,53 @@ func (bid *Bid) Validate() error {
 	} else if bid.ImpID == "" {
 		return ErrInvalidBidNoImpID
 	}
+	return nil
+}
+
+// ValidateCategories reports every category which is not part of its
+// taxonomy. Categories are only checked if cattax is set explicitly and a
+// table is available for the taxonomy, see RegisterTaxonomy.
+func (bid *Bid) ValidateCategories() error {
+	var errs violations
+	errs.categories("cat", bid.CategoryTaxonomy, bid.Categories)
+	return errors.Join(errs...)
+}
 
+func (bid *Bid) validateAll(errs *violations, path string) {
+	if bid.ID == "" {
+		errs.add(path, ErrInvalidBidNoID)
+	}
+	if bid.ImpID == "" {
+		errs.add(path, ErrInvalidBidNoImpID)
+	}
+}
+
+type jsonBid Bid
+
+// UnmarshalJSON implements json.Unmarshaler. For compatibility, apis is also
+// accepted as a single value, which previous versions of this package emitted.
+func (bid *Bid) UnmarshalJSON(data []byte) error {
+	var h struct {
+		jsonBid
+		APIS json.RawMessage `json:"apis,omitempty"`
+	}
+	if err := json.Unmarshal(data, &h); err != nil {
+		return err
+	}
+
+	*bid = (Bid)(h.jsonBid)
+
+	switch raw := bytes.TrimSpace(h.APIS); {
+	case len(raw) == 0 || bytes.Equal(raw, []byte("null")):
+		bid.APIS = nil
+	case raw[0] == '[':
+		return json.Unmarshal(raw, &bid.APIS)
+	default:
+		var api APIFramework
+		if err := json.Unmarshal(raw, &api); err != nil {
+			return err
+		}
+		bid.APIS = []APIFramework{api}
+	}
 	return nil
 }
@@ -2,6 +2,7 @@ package openrtb
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/goccy/go-json"
 )
@@ -34,10 +35,11 @@ type BidRequest struct {
 	User              *User             `json:"user,omitempty"`    // -
 	Source            *Source           `json:"source,omitempty"`  // A Source object that provides data about the inventory source and which entity makes the final decision
 	Regulations       *Regulations      `json:"regs,omitempty"`    // -
-	TimeMax           int16             `json:"tmax,omitempty"`    // Maximum amount of time in milliseconds to submit a bid
+	TimeMax           int               `json:"tmax,omitempty"`    // Maximum amount of time in milliseconds to submit a bid
 	Test              int8              `json:"test,omitempty"`    // Indicator of test mode in which auctions are not billable, where 0 = live mode, 1 = test mode
-	AuctionType       int8              `json:"at"`                // Auction type, where 1 = First Price, 2 = Second Price Plus. Exchange-specific auction types can be defined using values greater than 500.
+	AuctionType       int               `json:"at"`                // Auction type, where 1 = First Price, 2 = Second Price Plus. Exchange-specific auction types can be defined using values greater than 500.
 	AllImpressions    int8              `json:"allimps,omitempty"` // Flag to indicate whether exchange can verify that all impressions offered represent all of the impressions available in context, Default: 0
+	CategoryTaxonomy  CategoryTaxonomy  `json:"cattax,omitempty"`  // The taxonomy in use for bcat, default: IAB Content Taxonomy 1.0.
 }
 
 // Validate the request
@@ -59,3 +61,39 @@ func (req *BidRequest) Validate() error {
 
 	return nil
 }
+
+// ValidateAll is like Validate, but reports every violation instead of the
+// first one, each prefixed by the path of the offending object. The result
+// unwraps into the individual violations, see errors.Join.
+func (req *BidRequest) ValidateAll() error {
+	var errs violations
+	if req.ID == "" {
+		errs.add("", ErrInvalidReqNoID)
+	}
+	if len(req.Impressions) == 0 {
+		errs.add("", ErrInvalidReqNoImps)
+	}
+	if req.Site != nil && req.App != nil {
+		errs.add("", ErrInvalidReqMultiInv)
+	}
+
+	for i := range req.Impressions {
+		req.Impressions[i].validateAll(&errs, fmt.Sprintf("imp[%d]", i))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateCategories reports every category in bcat and in the site or app
+// object which is not part of its taxonomy, see Bid.ValidateCategories.
+func (req *BidRequest) ValidateCategories() error {
+	var errs violations
+	errs.categories("bcat", req.CategoryTaxonomy, req.BlockedCategories)
+	if req.Site != nil {
+		req.Site.validateCategories(&errs, "site")
+	}
+	if req.App != nil {
+		req.App.validateCategories(&errs, "app")
+	}
+	return errors.Join(errs...)
+}
@@ -86,3 +86,26 @@ func TestBidRequest_Validate(t *testing.T) {
 		t.Fatalf("expected %v, got %v", exp, got)
 	}
 }
+
+func TestBidRequest_ValidateAll(t *testing.T) {
+	subject := &BidRequest{Impressions: []Impression{{Video: &Video{}}}, Site: &Site{}, App: &App{}}
+	err := subject.ValidateAll()
+	for _, exp := range []error{ErrInvalidReqNoID, ErrInvalidReqMultiInv, ErrInvalidImpNoID, ErrInvalidVideoNoMIMEs, ErrInvalidVideoNoProtocols} {
+		if !errors.Is(err, exp) {
+			t.Errorf("expected %v in %v", exp, err)
+		}
+	}
+	if exp, got := "openrtb: request ID missing\n"+
+		"openrtb: request has multiple inventory sources\n"+
+		"imp[0]: openrtb: impression ID missing\n"+
+		"imp[0].video: openrtb: video has no mimes\n"+
+		"imp[0].video: openrtb: video linearity missing\n"+
+		"imp[0].video: openrtb: video protocols missing", err.Error(); exp != got {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+
+	subject = &BidRequest{ID: "A", Impressions: []Impression{{ID: "1"}}}
+	if err := subject.ValidateAll(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
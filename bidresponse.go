@@ -2,6 +2,7 @@ package openrtb
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/goccy/go-json"
 )
@@ -43,3 +44,32 @@ func (res *BidResponse) Validate() error {
 	}
 	return nil
 }
+
+// ValidateAll is like Validate, but reports every violation instead of the
+// first one, each prefixed by the path of the offending object.
+func (res *BidResponse) ValidateAll() error {
+	var errs violations
+	if res.ID == "" {
+		errs.add("", ErrInvalidRespNoID)
+	}
+	if len(res.SeatBids) == 0 {
+		errs.add("", ErrInvalidRespNoSeatBids)
+	}
+
+	for i := range res.SeatBids {
+		res.SeatBids[i].validateAll(&errs, fmt.Sprintf("seatbid[%d]", i))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateCategories reports every bid category which is not part of its
+// taxonomy, see Bid.ValidateCategories.
+func (res *BidResponse) ValidateCategories() error {
+	var errs violations
+	for i := range res.SeatBids {
+		for j, bid := range res.SeatBids[i].Bids {
+			errs.categories(fmt.Sprintf("seatbid[%d].bid[%d].cat", i, j), bid.CategoryTaxonomy, bid.Categories)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -64,3 +64,18 @@ func TestBidResponse_Validate(t *testing.T) {
 		t.Fatalf("expected %v, got %v", exp, got)
 	}
 }
+
+func TestBidResponse_ValidateAll(t *testing.T) {
+	subject := &BidResponse{SeatBids: []SeatBid{{}, {Bids: []Bid{{ID: "1"}, {ImpID: "1"}}}}}
+	if exp, got := "openrtb: response missing ID\n"+
+		"seatbid[0]: openrtb: seatbid is missing bids\n"+
+		"seatbid[1].bid[0]: openrtb: bid is missing impression ID\n"+
+		"seatbid[1].bid[1]: openrtb: bid is missing ID", subject.ValidateAll().Error(); exp != got {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+
+	subject = &BidResponse{ID: "A", SeatBids: []SeatBid{{Bids: []Bid{{ID: "1", ImpID: "1"}}}}}
+	if err := subject.ValidateAll(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
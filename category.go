@@ -0,0 +1,158 @@
+package openrtb
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/tomlightning/openrtb/v3/taxonomy"
+)
+
+// ErrInvalidCategory is returned by ValidateCategories for categories which
+// are not part of the taxonomy in use.
+var ErrInvalidCategory = errors.New("openrtb: category not in taxonomy")
+
+var taxonomies = struct {
+	sync.RWMutex
+	tables map[CategoryTaxonomy]*taxonomy.Taxonomy
+}{tables: make(map[CategoryTaxonomy]*taxonomy.Taxonomy)}
+
+// RegisterTaxonomy registers the table of a taxonomy, e.g. one parsed from
+// an official IAB Tech Lab release. Categories of taxonomies without a table
+// are not validated, a nil table removes a registration. IAB Content Taxonomy
+// 1.0 is embedded and used unless a different table is registered for it.
+func RegisterTaxonomy(tax CategoryTaxonomy, t *taxonomy.Taxonomy) {
+	taxonomies.Lock()
+	defer taxonomies.Unlock()
+
+	if t == nil {
+		delete(taxonomies.tables, tax)
+	} else {
+		taxonomies.tables[tax] = t
+	}
+}
+
+// Table returns the registered table of the taxonomy.
+func (x CategoryTaxonomy) Table() (*taxonomy.Taxonomy, bool) {
+	x = x.orDefault()
+
+	taxonomies.RLock()
+	t, ok := taxonomies.tables[x]
+	taxonomies.RUnlock()
+
+	if !ok && x == CategoryTaxonomyIABContent1 {
+		return taxonomy.Content1(), true
+	}
+	return t, ok
+}
+
+// orDefault returns IAB Content Taxonomy 1.0 if x is not set.
+func (x CategoryTaxonomy) orDefault() CategoryTaxonomy {
+	if x == 0 {
+		return CategoryTaxonomyIABContent1
+	}
+	return x
+}
+
+// Category is a category ID qualified by the taxonomy it belongs to.
+type Category struct {
+	Taxonomy CategoryTaxonomy
+	ID       ContentCategory
+}
+
+// Categories qualifies category IDs with their taxonomy, as given by the
+// cattax attribute of the object.
+func Categories(tax CategoryTaxonomy, ids []ContentCategory) []Category {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	res := make([]Category, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, Category{Taxonomy: tax.orDefault(), ID: id})
+	}
+	return res
+}
+
+// String returns the ID and the name of the taxonomy, e.g. "IAB1-1 (IABContent1)".
+func (c Category) String() string {
+	return string(c.ID) + " (" + c.Taxonomy.orDefault().String() + ")"
+}
+
+// Lookup returns the category from the table of its taxonomy.
+func (c Category) Lookup() (taxonomy.Category, bool) {
+	if t, ok := c.Taxonomy.Table(); ok {
+		return t.Lookup(string(c.ID))
+	}
+	return taxonomy.Category{}, false
+}
+
+// IsValid returns true if the category is part of its taxonomy. Categories
+// of taxonomies without a registered table are never valid.
+func (c Category) IsValid() bool {
+	_, ok := c.Lookup()
+	return ok
+}
+
+// Parent returns the parent category.
+func (c Category) Parent() (Category, bool) {
+	if t, ok := c.Taxonomy.Table(); ok {
+		if p, ok := t.Parent(string(c.ID)); ok {
+			return Category{Taxonomy: c.Taxonomy.orDefault(), ID: ContentCategory(p.ID)}, true
+		}
+	}
+	return Category{}, false
+}
+
+// Children returns the direct child categories.
+func (c Category) Children() []Category {
+	t, ok := c.Taxonomy.Table()
+	if !ok {
+		return nil
+	}
+
+	var res []Category
+	for _, child := range t.Children(string(c.ID)) {
+		res = append(res, Category{Taxonomy: c.Taxonomy.orDefault(), ID: ContentCategory(child.ID)})
+	}
+	return res
+}
+
+// Within returns true if c is the ancestor or one of its descendants, e.g.
+// to match a category against blocked categories.
+func (c Category) Within(ancestor Category) bool {
+	if c.Taxonomy.orDefault() != ancestor.Taxonomy.orDefault() {
+		return false
+	}
+	if t, ok := c.Taxonomy.Table(); ok {
+		return t.Within(string(c.ID), string(ancestor.ID))
+	}
+	return c.ID == ancestor.ID
+}
+
+// categories adds a violation for every category which is not part of the
+// table of the taxonomy. The implicit default taxonomy is not checked.
+func (v *violations) categories(path string, tax CategoryTaxonomy, ids []ContentCategory) {
+	if tax == 0 {
+		return
+	}
+	t, ok := tax.Table()
+	if !ok {
+		return
+	}
+
+	for _, id := range ids {
+		if !t.Contains(string(id)) {
+			v.add(path, fmt.Errorf("%w: %s", ErrInvalidCategory, Category{Taxonomy: tax, ID: id}))
+		}
+	}
+}
+
+func (inv *Inventory) validateCategories(errs *violations, path string) {
+	errs.categories(path+".cat", inv.CategoryTaxonomy, inv.Categories)
+	errs.categories(path+".sectioncat", inv.CategoryTaxonomy, inv.SectionCategories)
+	errs.categories(path+".pagecat", inv.CategoryTaxonomy, inv.PageCategories)
+	if c := inv.Content; c != nil {
+		errs.categories(path+".content.cat", c.CategoryTaxonomy, c.Categories)
+	}
+}
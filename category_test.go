@@ -0,0 +1,179 @@
+package openrtb_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/tomlightning/openrtb/v3"
+	"github.com/tomlightning/openrtb/v3/taxonomy"
+)
+
+func TestCategories(t *testing.T) {
+	if exp, got := []Category{
+		{Taxonomy: CategoryTaxonomyIABContent1, ID: "IAB1-1"},
+		{Taxonomy: CategoryTaxonomyIABContent1, ID: "IAB99"},
+	}, Categories(0, []ContentCategory{"IAB1-1", "IAB99"}); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got := Categories(CategoryTaxonomyIABContent3, nil); got != nil {
+		t.Errorf("expected no categories, got %v", got)
+	}
+}
+
+func TestCategory(t *testing.T) {
+	subject := Category{ID: ContentCategoryMusic}
+	if exp, got := "IAB1-6 (IABContent1)", subject.String(); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if !subject.IsValid() {
+		t.Errorf("expected %v to be valid", subject)
+	}
+
+	parent, ok := subject.Parent()
+	if exp := (Category{Taxonomy: CategoryTaxonomyIABContent1, ID: ContentCategoryArtsEntertainment}); !ok || exp != parent {
+		t.Errorf("expected %v, got %v", exp, parent)
+	}
+	if exp, got := 7, len(parent.Children()); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if !subject.Within(parent) || parent.Within(subject) {
+		t.Errorf("expected %v to be within %v only", subject, parent)
+	}
+
+	if (Category{ID: "IAB99"}).IsValid() {
+		t.Errorf("expected IAB99 to be invalid")
+	}
+
+	// taxonomies without a table
+	other := Category{Taxonomy: CategoryTaxonomyIABContent3, ID: "483"}
+	if other.IsValid() {
+		t.Errorf("expected %v to be invalid", other)
+	}
+	if _, ok := other.Parent(); ok {
+		t.Errorf("expected no parent for %v", other)
+	}
+	if !other.Within(other) || other.Within(Category{Taxonomy: CategoryTaxonomyIABContent1, ID: "483"}) {
+		t.Errorf("expected %v to be within itself only", other)
+	}
+}
+
+func TestRegisterTaxonomy(t *testing.T) {
+	table, err := taxonomy.Parse("Sample", strings.NewReader("Unique ID\tParent\tName\n483\t\tSports\n484\t483\tAmerican Football\n"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	RegisterTaxonomy(CategoryTaxonomyIABContent3, table)
+	t.Cleanup(func() { RegisterTaxonomy(CategoryTaxonomyIABContent3, nil) })
+
+	got, ok := CategoryTaxonomyIABContent3.Table()
+	if !ok || got != table {
+		t.Fatalf("expected %v, got %v", table, got)
+	}
+
+	subject := Category{Taxonomy: CategoryTaxonomyIABContent3, ID: "484"}
+	if !subject.IsValid() || !subject.Within(Category{Taxonomy: CategoryTaxonomyIABContent3, ID: "483"}) {
+		t.Errorf("expected %v to be valid and within 483", subject)
+	}
+	if _, ok := CategoryTaxonomyIABProduct2.Table(); ok {
+		t.Errorf("expected no table for %v", CategoryTaxonomyIABProduct2)
+	}
+}
+
+func TestBidRequest_ValidateCategories(t *testing.T) {
+	table, err := taxonomy.Parse("Sample", strings.NewReader("Unique ID\tParent\tName\n483\t\tSports\n"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	RegisterTaxonomy(CategoryTaxonomyIABContent3, table)
+	t.Cleanup(func() { RegisterTaxonomy(CategoryTaxonomyIABContent3, nil) })
+
+	newRequest := func() *BidRequest {
+		return &BidRequest{
+			ID:                "req",
+			Impressions:       []Impression{{ID: "1", Banner: &Banner{Width: 300, Height: 250}}},
+			BlockedCategories: []ContentCategory{"IAB25", "IAB26-1"},
+			CategoryTaxonomy:  CategoryTaxonomyIABContent1,
+			Site: &Site{Inventory: Inventory{
+				Categories:       []ContentCategory{"483"},
+				PageCategories:   []ContentCategory{"483"},
+				CategoryTaxonomy: CategoryTaxonomyIABContent3,
+				Content:          &Content{Categories: []ContentCategory{"IAB17"}, CategoryTaxonomy: CategoryTaxonomyIABContent1},
+			}},
+		}
+	}
+	if err := newRequest().ValidateCategories(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for exp, modify := range map[string]func(*BidRequest){
+		"bcat: openrtb: category not in taxonomy: IAB99 (IABContent1)": func(req *BidRequest) {
+			req.BlockedCategories = append(req.BlockedCategories, "IAB99")
+		},
+		"bcat: openrtb: category not in taxonomy: IAB25 (IABContent3)\n" +
+			"bcat: openrtb: category not in taxonomy: IAB26-1 (IABContent3)": func(req *BidRequest) {
+			req.CategoryTaxonomy = CategoryTaxonomyIABContent3
+		},
+		"site.pagecat: openrtb: category not in taxonomy: IAB17 (IABContent3)": func(req *BidRequest) {
+			req.Site.PageCategories = []ContentCategory{"IAB17"}
+		},
+		"site.content.cat: openrtb: category not in taxonomy: 483 (IABContent1)": func(req *BidRequest) {
+			req.Site.Content.Categories = []ContentCategory{"483"}
+		},
+		"app.cat: openrtb: category not in taxonomy: 483 (IABContent1)": func(req *BidRequest) {
+			req.App = &App{Inventory: Inventory{Categories: []ContentCategory{"483"}, CategoryTaxonomy: CategoryTaxonomyIABContent1}}
+			req.Site = nil
+		},
+	} {
+		req := newRequest()
+		modify(req)
+
+		err := req.ValidateCategories()
+		if !errors.Is(err, ErrInvalidCategory) {
+			t.Errorf("expected %v, got %v", ErrInvalidCategory, err)
+		} else if got := err.Error(); exp != got {
+			t.Errorf("expected %q, got %q", exp, got)
+		}
+		if err := req.Validate(); err != nil {
+			t.Errorf("expected Validate to ignore categories, got %v", err)
+		}
+	}
+
+	// the implicit default and taxonomies without a table are not validated
+	req := newRequest()
+	req.CategoryTaxonomy = 0
+	req.BlockedCategories = []ContentCategory{"483"}
+	if err := req.ValidateCategories(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	req.CategoryTaxonomy = CategoryTaxonomyIABProduct2
+	if err := req.ValidateCategories(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestBid_ValidateCategories(t *testing.T) {
+	subject := &Bid{ID: "1", ImpID: "1", Categories: []ContentCategory{"IAB3-1", "52"}}
+	if err := subject.ValidateCategories(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	subject.CategoryTaxonomy = CategoryTaxonomyIABContent22
+	if err := subject.ValidateCategories(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	subject.CategoryTaxonomy = CategoryTaxonomyIABContent1
+	if err := subject.ValidateCategories(); !errors.Is(err, ErrInvalidCategory) {
+		t.Errorf("expected %v, got %v", ErrInvalidCategory, err)
+	}
+	if err := subject.Validate(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	res := &BidResponse{ID: "1", SeatBids: []SeatBid{{Bids: []Bid{*subject}}}}
+	if exp, got := "seatbid[0].bid[0].cat: openrtb: category not in taxonomy: 52 (IABContent1)", res.ValidateCategories(); got == nil || exp != got.Error() {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
@@ -0,0 +1,700 @@
+// Code generated by clonegen. DO NOT EDIT.
+
+package openrtb
+
+// Clone returns a deep copy of the App.
+func (x *App) Clone() *App {
+	if x == nil {
+		return nil
+	}
+	c := new(App)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *App) cloneInto(c *App) {
+	*c = *x
+	x.Inventory.cloneInto(&c.Inventory)
+}
+
+// Clone returns a deep copy of the Audio.
+func (x *Audio) Clone() *Audio {
+	if x == nil {
+		return nil
+	}
+	c := new(Audio)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Audio) cloneInto(c *Audio) {
+	*c = *x
+	c.MIMEs = cloneSlice(x.MIMEs)
+	c.Protocols = cloneSlice(x.Protocols)
+	c.BlockedAttrs = cloneSlice(x.BlockedAttrs)
+	c.Delivery = cloneSlice(x.Delivery)
+	if x.CompanionAds != nil {
+		c.CompanionAds = make([]Banner, len(x.CompanionAds))
+		for i := range x.CompanionAds {
+			x.CompanionAds[i].cloneInto(&c.CompanionAds[i])
+		}
+	}
+	c.APIs = cloneSlice(x.APIs)
+	c.CompanionTypes = cloneSlice(x.CompanionTypes)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Banner.
+func (x *Banner) Clone() *Banner {
+	if x == nil {
+		return nil
+	}
+	c := new(Banner)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Banner) cloneInto(c *Banner) {
+	*c = *x
+	if x.Formats != nil {
+		c.Formats = make([]Format, len(x.Formats))
+		for i := range x.Formats {
+			x.Formats[i].cloneInto(&c.Formats[i])
+		}
+	}
+	c.BlockedTypes = cloneSlice(x.BlockedTypes)
+	c.BlockedAttrs = cloneSlice(x.BlockedAttrs)
+	c.MIMEs = cloneSlice(x.MIMEs)
+	c.ExpDirs = cloneSlice(x.ExpDirs)
+	c.APIs = cloneSlice(x.APIs)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Bid.
+func (x *Bid) Clone() *Bid {
+	if x == nil {
+		return nil
+	}
+	c := new(Bid)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Bid) cloneInto(c *Bid) {
+	*c = *x
+	c.AdvDomains = cloneSlice(x.AdvDomains)
+	c.Categories = cloneSlice(x.Categories)
+	c.Attrs = cloneSlice(x.Attrs)
+	c.Ext = cloneSlice(x.Ext)
+	c.APIS = cloneSlice(x.APIS)
+	c.DSA = x.DSA.Clone()
+}
+
+// Clone returns a deep copy of the BidRequest.
+func (x *BidRequest) Clone() *BidRequest {
+	if x == nil {
+		return nil
+	}
+	c := new(BidRequest)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *BidRequest) cloneInto(c *BidRequest) {
+	*c = *x
+	if x.Impressions != nil {
+		c.Impressions = make([]Impression, len(x.Impressions))
+		for i := range x.Impressions {
+			x.Impressions[i].cloneInto(&c.Impressions[i])
+		}
+	}
+	c.Seats = cloneSlice(x.Seats)
+	c.BlockedSeats = cloneSlice(x.BlockedSeats)
+	c.Languages = cloneSlice(x.Languages)
+	c.LanguagesB = cloneSlice(x.LanguagesB)
+	c.Currencies = cloneSlice(x.Currencies)
+	c.BlockedCategories = cloneSlice(x.BlockedCategories)
+	c.BlockedAdvDomains = cloneSlice(x.BlockedAdvDomains)
+	c.BlockedApps = cloneSlice(x.BlockedApps)
+	c.Ext = cloneSlice(x.Ext)
+	c.Site = x.Site.Clone()
+	c.App = x.App.Clone()
+	c.Device = x.Device.Clone()
+	c.User = x.User.Clone()
+	c.Source = x.Source.Clone()
+	c.Regulations = x.Regulations.Clone()
+}
+
+// Clone returns a deep copy of the BidResponse.
+func (x *BidResponse) Clone() *BidResponse {
+	if x == nil {
+		return nil
+	}
+	c := new(BidResponse)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *BidResponse) cloneInto(c *BidResponse) {
+	*c = *x
+	if x.SeatBids != nil {
+		c.SeatBids = make([]SeatBid, len(x.SeatBids))
+		for i := range x.SeatBids {
+			x.SeatBids[i].cloneInto(&c.SeatBids[i])
+		}
+	}
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the BrandVersion.
+func (x *BrandVersion) Clone() *BrandVersion {
+	if x == nil {
+		return nil
+	}
+	c := new(BrandVersion)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *BrandVersion) cloneInto(c *BrandVersion) {
+	*c = *x
+	c.Version = cloneSlice(x.Version)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the ChannelEntity.
+func (x *ChannelEntity) Clone() *ChannelEntity {
+	if x == nil {
+		return nil
+	}
+	c := new(ChannelEntity)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *ChannelEntity) cloneInto(c *ChannelEntity) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Content.
+func (x *Content) Clone() *Content {
+	if x == nil {
+		return nil
+	}
+	c := new(Content)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Content) cloneInto(c *Content) {
+	*c = *x
+	c.Categories = cloneSlice(x.Categories)
+	if x.Data != nil {
+		c.Data = make([]Data, len(x.Data))
+		for i := range x.Data {
+			x.Data[i].cloneInto(&c.Data[i])
+		}
+	}
+	c.KwArray = cloneSlice(x.KwArray)
+	c.Ext = cloneSlice(x.Ext)
+	c.Producer = x.Producer.Clone()
+	c.Network = x.Network.Clone()
+	c.Channel = x.Channel.Clone()
+}
+
+// Clone returns a deep copy of the DSA.
+func (x *DSA) Clone() *DSA {
+	if x == nil {
+		return nil
+	}
+	c := new(DSA)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *DSA) cloneInto(c *DSA) {
+	*c = *x
+	if x.Transparency != nil {
+		c.Transparency = make([]DSATransparency, len(x.Transparency))
+		for i := range x.Transparency {
+			x.Transparency[i].cloneInto(&c.Transparency[i])
+		}
+	}
+}
+
+// Clone returns a deep copy of the DSATransparency.
+func (x *DSATransparency) Clone() *DSATransparency {
+	if x == nil {
+		return nil
+	}
+	c := new(DSATransparency)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *DSATransparency) cloneInto(c *DSATransparency) {
+	*c = *x
+	c.Params = cloneSlice(x.Params)
+}
+
+// Clone returns a deep copy of the Data.
+func (x *Data) Clone() *Data {
+	if x == nil {
+		return nil
+	}
+	c := new(Data)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Data) cloneInto(c *Data) {
+	*c = *x
+	if x.Segment != nil {
+		c.Segment = make([]Segment, len(x.Segment))
+		for i := range x.Segment {
+			x.Segment[i].cloneInto(&c.Segment[i])
+		}
+	}
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Deal.
+func (x *Deal) Clone() *Deal {
+	if x == nil {
+		return nil
+	}
+	c := new(Deal)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Deal) cloneInto(c *Deal) {
+	*c = *x
+	c.Seats = cloneSlice(x.Seats)
+	c.AdvDomains = cloneSlice(x.AdvDomains)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Device.
+func (x *Device) Clone() *Device {
+	if x == nil {
+		return nil
+	}
+	c := new(Device)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Device) cloneInto(c *Device) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+	c.Sua = x.Sua.Clone()
+	c.Geo = x.Geo.Clone()
+}
+
+// Clone returns a deep copy of the EID.
+func (x *EID) Clone() *EID {
+	if x == nil {
+		return nil
+	}
+	c := new(EID)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *EID) cloneInto(c *EID) {
+	*c = *x
+	if x.UIDs != nil {
+		c.UIDs = make([]UID, len(x.UIDs))
+		for i := range x.UIDs {
+			x.UIDs[i].cloneInto(&c.UIDs[i])
+		}
+	}
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Format.
+func (x *Format) Clone() *Format {
+	if x == nil {
+		return nil
+	}
+	c := new(Format)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Format) cloneInto(c *Format) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Geo.
+func (x *Geo) Clone() *Geo {
+	if x == nil {
+		return nil
+	}
+	c := new(Geo)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Geo) cloneInto(c *Geo) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Impression.
+func (x *Impression) Clone() *Impression {
+	if x == nil {
+		return nil
+	}
+	c := new(Impression)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Impression) cloneInto(c *Impression) {
+	*c = *x
+	c.IFrameBusters = cloneSlice(x.IFrameBusters)
+	c.Ext = cloneSlice(x.Ext)
+	c.Banner = x.Banner.Clone()
+	c.Video = x.Video.Clone()
+	c.Audio = x.Audio.Clone()
+	c.Native = x.Native.Clone()
+	c.PMP = x.PMP.Clone()
+}
+
+// Clone returns a deep copy of the Inventory.
+func (x *Inventory) Clone() *Inventory {
+	if x == nil {
+		return nil
+	}
+	c := new(Inventory)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Inventory) cloneInto(c *Inventory) {
+	*c = *x
+	c.Categories = cloneSlice(x.Categories)
+	c.SectionCategories = cloneSlice(x.SectionCategories)
+	c.PageCategories = cloneSlice(x.PageCategories)
+	if x.PrivacyPolicy != nil {
+		v := *x.PrivacyPolicy
+		c.PrivacyPolicy = &v
+	}
+	c.Publisher = x.Publisher.Clone()
+	c.Content = x.Content.Clone()
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Native.
+func (x *Native) Clone() *Native {
+	if x == nil {
+		return nil
+	}
+	c := new(Native)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Native) cloneInto(c *Native) {
+	*c = *x
+	c.Request = cloneSlice(x.Request)
+	c.APIs = cloneSlice(x.APIs)
+	c.BlockedAttrs = cloneSlice(x.BlockedAttrs)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the PMP.
+func (x *PMP) Clone() *PMP {
+	if x == nil {
+		return nil
+	}
+	c := new(PMP)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *PMP) cloneInto(c *PMP) {
+	*c = *x
+	if x.Deals != nil {
+		c.Deals = make([]Deal, len(x.Deals))
+		for i := range x.Deals {
+			x.Deals[i].cloneInto(&c.Deals[i])
+		}
+	}
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Producer.
+func (x *Producer) Clone() *Producer {
+	if x == nil {
+		return nil
+	}
+	c := new(Producer)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Producer) cloneInto(c *Producer) {
+	*c = *x
+	c.Categories = cloneSlice(x.Categories)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Publisher.
+func (x *Publisher) Clone() *Publisher {
+	if x == nil {
+		return nil
+	}
+	c := new(Publisher)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Publisher) cloneInto(c *Publisher) {
+	*c = *x
+	c.Categories = cloneSlice(x.Categories)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Regulations.
+func (x *Regulations) Clone() *Regulations {
+	if x == nil {
+		return nil
+	}
+	c := new(Regulations)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Regulations) cloneInto(c *Regulations) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the SeatBid.
+func (x *SeatBid) Clone() *SeatBid {
+	if x == nil {
+		return nil
+	}
+	c := new(SeatBid)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *SeatBid) cloneInto(c *SeatBid) {
+	*c = *x
+	if x.Bids != nil {
+		c.Bids = make([]Bid, len(x.Bids))
+		for i := range x.Bids {
+			x.Bids[i].cloneInto(&c.Bids[i])
+		}
+	}
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Segment.
+func (x *Segment) Clone() *Segment {
+	if x == nil {
+		return nil
+	}
+	c := new(Segment)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Segment) cloneInto(c *Segment) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Site.
+func (x *Site) Clone() *Site {
+	if x == nil {
+		return nil
+	}
+	c := new(Site)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Site) cloneInto(c *Site) {
+	*c = *x
+	x.Inventory.cloneInto(&c.Inventory)
+}
+
+// Clone returns a deep copy of the Source.
+func (x *Source) Clone() *Source {
+	if x == nil {
+		return nil
+	}
+	c := new(Source)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Source) cloneInto(c *Source) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+	c.SChain = x.SChain.Clone()
+}
+
+// Clone returns a deep copy of the SupplyChain.
+func (x *SupplyChain) Clone() *SupplyChain {
+	if x == nil {
+		return nil
+	}
+	c := new(SupplyChain)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *SupplyChain) cloneInto(c *SupplyChain) {
+	*c = *x
+	if x.Nodes != nil {
+		c.Nodes = make([]SupplyChainNode, len(x.Nodes))
+		for i := range x.Nodes {
+			x.Nodes[i].cloneInto(&c.Nodes[i])
+		}
+	}
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the SupplyChainNode.
+func (x *SupplyChainNode) Clone() *SupplyChainNode {
+	if x == nil {
+		return nil
+	}
+	c := new(SupplyChainNode)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *SupplyChainNode) cloneInto(c *SupplyChainNode) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the ThirdParty.
+func (x *ThirdParty) Clone() *ThirdParty {
+	if x == nil {
+		return nil
+	}
+	c := new(ThirdParty)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *ThirdParty) cloneInto(c *ThirdParty) {
+	*c = *x
+	c.Categories = cloneSlice(x.Categories)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the UID.
+func (x *UID) Clone() *UID {
+	if x == nil {
+		return nil
+	}
+	c := new(UID)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *UID) cloneInto(c *UID) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the User.
+func (x *User) Clone() *User {
+	if x == nil {
+		return nil
+	}
+	c := new(User)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *User) cloneInto(c *User) {
+	*c = *x
+	c.Geo = x.Geo.Clone()
+	if x.Data != nil {
+		c.Data = make([]Data, len(x.Data))
+		for i := range x.Data {
+			x.Data[i].cloneInto(&c.Data[i])
+		}
+	}
+	if x.EIDs != nil {
+		c.EIDs = make([]EID, len(x.EIDs))
+		for i := range x.EIDs {
+			x.EIDs[i].cloneInto(&c.EIDs[i])
+		}
+	}
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the UserAgent.
+func (x *UserAgent) Clone() *UserAgent {
+	if x == nil {
+		return nil
+	}
+	c := new(UserAgent)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *UserAgent) cloneInto(c *UserAgent) {
+	*c = *x
+	if x.Browsers != nil {
+		c.Browsers = make([]BrandVersion, len(x.Browsers))
+		for i := range x.Browsers {
+			x.Browsers[i].cloneInto(&c.Browsers[i])
+		}
+	}
+	x.Platform.cloneInto(&c.Platform)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Video.
+func (x *Video) Clone() *Video {
+	if x == nil {
+		return nil
+	}
+	c := new(Video)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Video) cloneInto(c *Video) {
+	*c = *x
+	c.MIMEs = cloneSlice(x.MIMEs)
+	c.Protocols = cloneSlice(x.Protocols)
+	c.BlockedAttrs = cloneSlice(x.BlockedAttrs)
+	c.RqdDurs = cloneSlice(x.RqdDurs)
+	c.PlaybackMethods = cloneSlice(x.PlaybackMethods)
+	c.Delivery = cloneSlice(x.Delivery)
+	if x.CompanionAds != nil {
+		c.CompanionAds = make([]Banner, len(x.CompanionAds))
+		for i := range x.CompanionAds {
+			x.CompanionAds[i].cloneInto(&c.CompanionAds[i])
+		}
+	}
+	c.APIs = cloneSlice(x.APIs)
+	c.CompanionTypes = cloneSlice(x.CompanionTypes)
+	c.Ext = cloneSlice(x.Ext)
+	if x.BoxingAllowed != nil {
+		v := *x.BoxingAllowed
+		c.BoxingAllowed = &v
+	}
+}
+
+func cloneSlice[E any](s []E) []E {
+	if s == nil {
+		return nil
+	}
+	return append(make([]E, 0, len(s)), s...)
+}
@@ -0,0 +1,89 @@
+package openrtb_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomlightning/openrtb/v3/internal/clonetest"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestBidRequest_Clone(t *testing.T) {
+	for _, name := range []string{"breq.banner", "breq.exp", "breq.native", "breq.video"} {
+		var subject *BidRequest
+		if err := fixture(name, &subject); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		clone := subject.Clone()
+		if !reflect.DeepEqual(subject, clone) {
+			t.Errorf("expected %+v, got %+v", subject, clone)
+		}
+		if paths := clonetest.Aliases(subject, clone); len(paths) != 0 {
+			t.Errorf("expected no aliasing in %s, got %v", name, paths)
+		}
+	}
+}
+
+func TestBidRequest_Clone_filled(t *testing.T) {
+	subject := new(BidRequest)
+	clonetest.Fill(subject)
+
+	clone := subject.Clone()
+	if !reflect.DeepEqual(subject, clone) {
+		t.Errorf("expected %+v, got %+v", subject, clone)
+	}
+	if paths := clonetest.Aliases(subject, clone); len(paths) != 0 {
+		t.Errorf("expected no aliasing, got %v", paths)
+	}
+
+	shallow := *subject
+	if paths := clonetest.Aliases(subject, &shallow); len(paths) == 0 {
+		t.Errorf("expected aliasing in shallow copy")
+	}
+
+	clone.Impressions[0].Video.CompanionAds[0].Formats[0].Ext[0] = '['
+	*clone.Site.PrivacyPolicy = 0
+	clone.Device.Sua.Browsers[1].Version[0] = "y"
+	if exp, got := byte('{'), subject.Impressions[0].Video.CompanionAds[0].Formats[0].Ext[0]; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := 1, *subject.Site.PrivacyPolicy; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := "x", subject.Device.Sua.Browsers[1].Version[0]; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestBidResponse_Clone(t *testing.T) {
+	subject := new(BidResponse)
+	clonetest.Fill(subject)
+
+	clone := subject.Clone()
+	if !reflect.DeepEqual(subject, clone) {
+		t.Errorf("expected %+v, got %+v", subject, clone)
+	}
+	if paths := clonetest.Aliases(subject, clone); len(paths) != 0 {
+		t.Errorf("expected no aliasing, got %v", paths)
+	}
+
+	var empty *BidResponse
+	if empty.Clone() != nil {
+		t.Errorf("expected nil clone")
+	}
+}
+
+func TestPublisher_Clone(t *testing.T) {
+	subject := new(Publisher)
+	clonetest.Fill(subject)
+
+	clone := subject.Clone()
+	if !reflect.DeepEqual(subject, clone) {
+		t.Errorf("expected %+v, got %+v", subject, clone)
+	}
+	if paths := clonetest.Aliases(subject, clone); len(paths) != 0 {
+		t.Errorf("expected no aliasing, got %v", paths)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomlightning/openrtb/v3"
+)
+
+func runConvert(args []string, stdout, stderr io.Writer) error {
+	kind := kindAuto
+
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Var(&kind, "type", "document type: auto, request or response")
+	to := fs.String("to", "jsonl", "output format: json or jsonl")
+	version := fs.String("version", "2.6", "target OpenRTB version: 2.6, 2.5 or 2.4")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	target := openrtb.Version(*version)
+	switch target {
+	case openrtb.Version26, openrtb.Version25, openrtb.Version24:
+	default:
+		return fmt.Errorf("unsupported target version %q", *version)
+	}
+
+	switch *to {
+	case "json", "jsonl":
+	default:
+		return fmt.Errorf("unsupported output format %q", *to)
+	}
+
+	var docs []interface{}
+	err := readRecords(fs.Args(), func(rec *record) error {
+		doc, err := rec.Decode(kind, openrtb.DecodeLenient)
+		if err != nil {
+			return fmt.Errorf("%s: %w", rec, err)
+		}
+
+		data, err := marshalFor(doc, target)
+		if err != nil {
+			return fmt.Errorf("%s: %w", rec, err)
+		}
+
+		if *to == "json" {
+			docs = append(docs, json.RawMessage(data))
+			return nil
+		}
+		_, err = fmt.Fprintf(stdout, "%s\n", data)
+		return err
+	})
+	if err != nil || *to != "json" {
+		return err
+	}
+
+	var v interface{} = docs
+	if len(docs) == 1 {
+		v = docs[0]
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "%s\n", data)
+	return err
+}
+
+// marshalFor encodes doc for the target version. Only requests can be
+// converted to earlier versions.
+func marshalFor(doc interface{}, target openrtb.Version) ([]byte, error) {
+	if req, ok := doc.(*openrtb.BidRequest); ok {
+		return req.MarshalFor(target)
+	}
+	if target != openrtb.Version26 {
+		return nil, fmt.Errorf("cannot convert responses to version %s", target)
+	}
+	return json.Marshal(doc)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomlightning/openrtb/v3"
+)
+
+func runFmt(args []string, stdout, stderr io.Writer) error {
+	kind := kindAuto
+
+	fs := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Var(&kind, "type", "document type: auto, request or response")
+	compact := fs.Bool("c", false, "compact output, one document per line")
+	indent := fs.String("indent", "  ", "indentation string")
+	norm := fs.Bool("normalize", false, "promote ext members into 2.6 fields, canonicalize IPs and geo codes")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return readRecords(fs.Args(), func(rec *record) error {
+		doc, err := rec.Decode(kind, openrtb.DecodeLenient)
+		if err != nil {
+			return fmt.Errorf("%s: %w", rec, err)
+		}
+
+		if *norm {
+			for _, err := range normalize(doc) {
+				fmt.Fprintf(stderr, "%s: %v\n", rec, err)
+			}
+		}
+
+		var data []byte
+		if *compact {
+			data, err = json.Marshal(doc)
+		} else {
+			data, err = json.MarshalIndent(doc, "", *indent)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", rec, err)
+		}
+
+		_, err = fmt.Fprintf(stdout, "%s\n", data)
+		return err
+	})
+}
+
+// normalize rewrites doc into its canonical 2.6 form. Values which cannot be
+// normalized are kept and reported.
+func normalize(doc interface{}) []error {
+	var errs []error
+	switch v := doc.(type) {
+	case *openrtb.BidRequest:
+		if _, err := v.Normalize(nil); err != nil {
+			errs = append(errs, err)
+		}
+		if d := v.Device; d != nil {
+			if err := d.NormalizeIP(); err != nil {
+				errs = append(errs, fmt.Errorf("device: %w", err))
+			}
+			if d.Geo != nil {
+				if err := d.Geo.Normalize(); err != nil {
+					errs = append(errs, fmt.Errorf("device.geo: %w", err))
+				}
+			}
+		}
+		if u := v.User; u != nil && u.Geo != nil {
+			if err := u.Geo.Normalize(); err != nil {
+				errs = append(errs, fmt.Errorf("user.geo: %w", err))
+			}
+		}
+	case *openrtb.BidResponse:
+		if _, err := v.Normalize(nil); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,209 @@
+// Command ortb is a small toolbox for working with OpenRTB documents.
+//
+// Usage:
+//
+//	ortb validate [flags] FILE...
+//	ortb fmt      [flags] FILE...
+//	ortb convert  [flags] FILE...
+//	ortb stats    [flags] FILE...
+//
+// Each FILE may contain a single JSON document, a JSON array of documents
+// or a stream of newline-delimited documents (JSONL). Use "-" to read
+// from STDIN.
+//
+// Documents are converted between the 2.x dialects only, OpenRTB 3.0 and
+// protobuf encodings are not supported.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomlightning/openrtb/v3"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdout, stderr io.Writer) error
+}
+
+var commands = []command{
+	{name: "validate", usage: "validate documents and print every violation", run: runValidate},
+	{name: "fmt", usage: "pretty-print and optionally normalize documents", run: runFmt},
+	{name: "convert", usage: "convert documents between JSON, JSONL and the 2.6, 2.5 and 2.4 dialects", run: runConvert},
+	{name: "stats", usage: "print field coverage and enum distributions", run: runStats},
+}
+
+// errViolations is returned by commands which completed but found problems.
+var errViolations = errors.New("violations found")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		if err := cmd.run(args[1:], stdout, stderr); errors.Is(err, errViolations) {
+			return 1
+		} else if err != nil {
+			fmt.Fprintf(stderr, "ortb %s: %v\n", cmd.name, err)
+			return 1
+		}
+		return 0
+	}
+
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: ortb COMMAND [flags] FILE...")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+}
+
+// --------------------------------------------------------------------
+
+// docKind identifies the type of an OpenRTB document.
+type docKind string
+
+const (
+	kindAuto     docKind = "auto"
+	kindRequest  docKind = "request"
+	kindResponse docKind = "response"
+)
+
+func (k *docKind) String() string { return string(*k) }
+
+func (k *docKind) Set(s string) error {
+	switch v := docKind(s); v {
+	case kindAuto, kindRequest, kindResponse:
+		*k = v
+		return nil
+	}
+	return fmt.Errorf("invalid document type %q", s)
+}
+
+// record is a single raw document read from an input.
+type record struct {
+	Source string
+	Index  int
+	Data   json.RawMessage
+}
+
+func (r *record) String() string {
+	return fmt.Sprintf("%s#%d", r.Source, r.Index)
+}
+
+// Kind detects the document kind, unless forced.
+func (r *record) Kind(force docKind) docKind {
+	if force != kindAuto {
+		return force
+	}
+
+	var probe struct {
+		SeatBids json.RawMessage `json:"seatbid"`
+		NBR      json.RawMessage `json:"nbr"`
+	}
+	if err := json.Unmarshal(r.Data, &probe); err == nil && (probe.SeatBids != nil || probe.NBR != nil) {
+		return kindResponse
+	}
+	return kindRequest
+}
+
+// Decode decodes the record into a typed document.
+func (r *record) Decode(force docKind, mode openrtb.DecodeMode) (interface{}, error) {
+	v, _, err := r.DecodeWithReport(force, mode)
+	return v, err
+}
+
+// DecodeWithReport decodes the record into a typed document and returns the decode report.
+func (r *record) DecodeWithReport(force docKind, mode openrtb.DecodeMode) (interface{}, *openrtb.DecodeReport, error) {
+	var v interface{}
+	switch r.Kind(force) {
+	case kindResponse:
+		v = new(openrtb.BidResponse)
+	default:
+		v = new(openrtb.BidRequest)
+	}
+
+	report, err := openrtb.Decode(r.Data, v, mode)
+	if err != nil {
+		return nil, report, err
+	}
+	return v, report, nil
+}
+
+// readRecords reads all records from the named files.
+func readRecords(names []string, fn func(*record) error) error {
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+
+	for _, name := range names {
+		if err := readFile(name, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readFile(name string, fn func(*record) error) error {
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		r = f
+	}
+	return readStream(name, r, fn)
+}
+
+func readStream(name string, r io.Reader, fn func(*record) error) error {
+	dec := json.NewDecoder(r)
+	index := 0
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("%s#%d: %w", name, index+1, err)
+		}
+
+		docs := []json.RawMessage{raw}
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) != 0 && trimmed[0] == '[' {
+			docs = docs[:0]
+			if err := json.Unmarshal(trimmed, &docs); err != nil {
+				return fmt.Errorf("%s#%d: %w", name, index+1, err)
+			}
+		}
+
+		for _, doc := range docs {
+			index++
+			if err := fn(&record{Source: name, Index: index, Data: doc}); err != nil {
+				return err
+			}
+		}
+	}
+}
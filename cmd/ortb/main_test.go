@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tomlightning/openrtb/v3"
+)
+
+func TestReadStream(t *testing.T) {
+	for _, input := range []string{
+		`{"id":"1"}` + "\n" + `{"id":"2"}` + "\n" + `{"id":"3"}`,
+		`[{"id":"1"},{"id":"2"}] {"id":"3"}`,
+	} {
+		var ids []string
+		err := readStream("in", strings.NewReader(input), func(rec *record) error {
+			ids = append(ids, rec.String())
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if exp, got := "in#1,in#2,in#3", strings.Join(ids, ","); exp != got {
+			t.Errorf("expected %v, got %v", exp, got)
+		}
+	}
+}
+
+func TestRecord_Kind(t *testing.T) {
+	for input, exp := range map[string]docKind{
+		`{"id":"1","imp":[]}`:     kindRequest,
+		`{"id":"1","seatbid":[]}`: kindResponse,
+		`{"id":"1","nbr":2}`:      kindResponse,
+	} {
+		rec := &record{Data: []byte(input)}
+		if got := rec.Kind(kindAuto); exp != got {
+			t.Errorf("expected %v, got %v for %s", exp, got, input)
+		}
+	}
+}
+
+func TestRun_validate(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if exp, got := 0, run([]string{"validate", "../../testdata/breq.video.json"}, &stdout, &stderr); exp != got {
+		t.Fatalf("expected %v, got %v (%s)", exp, got, stderr.String())
+	}
+	if exp, got := "1 documents, 0 invalid\n", stdout.String(); exp != got {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+
+	fname := filepath.Join(t.TempDir(), "invalid.jsonl")
+	if err := os.WriteFile(fname, []byte(`{"id":"1"}`+"\n"+`{"imp":[{"id":"1"}]}`), 0o644); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	stdout.Reset()
+	if exp, got := 1, run([]string{"validate", fname}, &stdout, &stderr); exp != got {
+		t.Fatalf("expected %v, got %v", exp, got)
+	}
+	if exp, got := fname+"#1: openrtb: request has no impressions\n"+
+		fname+"#2: openrtb: request ID missing\n"+
+		"2 documents, 2 invalid\n", stdout.String(); exp != got {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+}
+
+func TestStats(t *testing.T) {
+	subject := newStats()
+	err := readFile("../../testdata/breq.video.json", func(rec *record) error {
+		doc, err := rec.Decode(kindAuto, openrtb.DecodeLenient)
+		if err != nil {
+			return err
+		}
+		subject.Add(doc)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if exp, got := 1, subject.Coverage["imp[].video.protocols"]; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := 6, subject.Enums["imp[].video.protocols[]"][2]+subject.Enums["imp[].video.protocols[]"][3]; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := 3, subject.Enums["imp[].video.pos"][1]; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if _, ok := subject.Coverage["app"]; ok {
+		t.Errorf("expected no app coverage")
+	}
+}
+
+func TestRun_validateStrict(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if exp, got := 1, run([]string{"validate", "-strict", "../../testdata/breq.violations.json"}, &stdout, &stderr); exp != got {
+		t.Fatalf("expected %v, got %v (%s)", exp, got, stderr.String())
+	}
+	if exp, got := "../../testdata/breq.violations.json#1: openrtb: unknown field at custom: {\"nested\":true}\n", stdout.String(); !strings.HasPrefix(got, exp) {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+	if exp, got := 7, strings.Count(stdout.String(), "\n"); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestRun_convertVersion(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if exp, got := 0, run([]string{"convert", "-version", "2.5", "../../testdata/breq.v26.json"}, &stdout, &stderr); exp != got {
+		t.Fatalf("expected %v, got %v (%s)", exp, got, stderr.String())
+	}
+	if out := stdout.String(); !strings.Contains(out, `"regs":{"ext":{"gdpr":1,"us_privacy":"1YNN"}}`) || strings.Contains(out, `"plcmt"`) {
+		t.Errorf("expected 2.5 request, got %s", out)
+	}
+
+	stdout.Reset()
+	if exp, got := 1, run([]string{"convert", "-version", "2.5", "../../testdata/bres.single.json"}, &stdout, &stderr); exp != got {
+		t.Fatalf("expected %v, got %v", exp, got)
+	}
+	if exp, got := 1, run([]string{"convert", "-version", "3.0", "../../testdata/breq.v26.json"}, &stdout, &stderr); exp != got {
+		t.Fatalf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestRun_validateAll(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "invalid.json")
+	if err := os.WriteFile(fname, []byte(`{"imp":[{"video":{"mimes":["video/mp4"]}}],"bcat":["IAB1","XYZ"],"cattax":1}`), 0o644); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if exp, got := 1, run([]string{"validate", "-q", "-categories", fname}, &stdout, &stderr); exp != got {
+		t.Fatalf("expected %v, got %v (%s)", exp, got, stderr.String())
+	}
+	if exp, got := fname+"#1: openrtb: request ID missing\n"+
+		fname+"#1: imp[0]: openrtb: impression ID missing\n"+
+		fname+"#1: imp[0].video: openrtb: video protocols missing\n"+
+		fname+"#1: bcat: openrtb: category not in taxonomy: XYZ (IABContent1)\n", stdout.String(); exp != got {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+
+	stdout.Reset()
+	if exp, got := 1, run([]string{"validate", "-q", fname}, &stdout, &stderr); exp != got {
+		t.Fatalf("expected %v, got %v (%s)", exp, got, stderr.String())
+	}
+	if got := stdout.String(); strings.Contains(got, "bcat") {
+		t.Errorf("expected categories to be ignored, got %q", got)
+	}
+}
+
+func TestRun_fmt(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "breq.json")
+	if err := os.WriteFile(fname, []byte(`{"id":"1","imp":[{"id":"1"}],"device":{"ipv6":"::ffff:192.0.2.1","geo":{"country":"de"}},"regs":{"ext":{"gdpr":1}}}`), 0o644); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if exp, got := 0, run([]string{"fmt", "-c", fname}, &stdout, &stderr); exp != got {
+		t.Fatalf("expected %v, got %v (%s)", exp, got, stderr.String())
+	}
+	if got := stdout.String(); strings.Contains(got, `"country":"DEU"`) {
+		t.Errorf("expected no normalization, got %s", got)
+	}
+
+	stdout.Reset()
+	if exp, got := 0, run([]string{"fmt", "-c", "-normalize", fname}, &stdout, &stderr); exp != got {
+		t.Fatalf("expected %v, got %v (%s)", exp, got, stderr.String())
+	}
+	for _, exp := range []string{`"ip":"192.0.2.1"`, `"country":"DEU"`, `"regs":{"ext":{"gdpr":1},"gdpr":1}`} {
+		if got := stdout.String(); !strings.Contains(got, exp) {
+			t.Errorf("expected %s in %s", exp, got)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/tomlightning/openrtb/v3"
+)
+
+const modulePath = "github.com/tomlightning/openrtb/v3"
+
+func runStats(args []string, stdout, stderr io.Writer) error {
+	kind := kindAuto
+
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Var(&kind, "type", "document type: auto, request or response")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	st := newStats()
+	err := readRecords(fs.Args(), func(rec *record) error {
+		doc, err := rec.Decode(kind, openrtb.DecodeLenient)
+		if err != nil {
+			st.Failed++
+			return nil
+		}
+		st.Add(doc)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	st.Print(stdout)
+	return nil
+}
+
+// stats collects field coverage and enum distributions.
+type stats struct {
+	Documents int
+	Failed    int
+	Coverage  map[string]int           // number of documents with the path present
+	Enums     map[string]map[int64]int // path -> value -> occurrences
+
+	seen map[string]struct{}
+}
+
+func newStats() *stats {
+	return &stats{
+		Coverage: make(map[string]int),
+		Enums:    make(map[string]map[int64]int),
+		seen:     make(map[string]struct{}),
+	}
+}
+
+// Add adds a decoded document to the stats.
+func (s *stats) Add(doc interface{}) {
+	s.Documents++
+	for k := range s.seen {
+		delete(s.seen, k)
+	}
+
+	s.walk(reflect.ValueOf(doc), "")
+	for path := range s.seen {
+		s.Coverage[path]++
+	}
+}
+
+func (s *stats) walk(v reflect.Value, path string) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			s.walk(v.Elem(), path)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field, fv := t.Field(i), v.Field(i)
+			if field.Anonymous {
+				s.walk(fv, path)
+				continue
+			}
+
+			name := jsonName(field)
+			if name == "" || fv.IsZero() {
+				continue
+			}
+
+			sub := name
+			if path != "" {
+				sub = path + "." + name
+			}
+			s.seen[sub] = struct{}{}
+			s.walk(fv, sub)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			s.walk(v.Index(i), path+"[]")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if isEnum(v.Type()) {
+			dist, ok := s.Enums[path]
+			if !ok {
+				dist = make(map[int64]int)
+				s.Enums[path] = dist
+			}
+			dist[v.Int()]++
+		}
+	}
+}
+
+// Print prints a report.
+func (s *stats) Print(w io.Writer) {
+	fmt.Fprintf(w, "documents: %d (%d failed to decode)\n\n", s.Documents, s.Failed)
+
+	fmt.Fprintln(w, "field coverage:")
+	for _, path := range sortedKeys(s.Coverage) {
+		n := s.Coverage[path]
+		fmt.Fprintf(w, "  %-40s %8d %6.1f%%\n", path, n, percent(n, s.Documents))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "enum distribution:")
+	for _, path := range sortedKeys(s.Enums) {
+		dist := s.Enums[path]
+
+		values := make([]int64, 0, len(dist))
+		total := 0
+		for v, n := range dist {
+			values = append(values, v)
+			total += n
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+		fmt.Fprintf(w, "  %s\n", path)
+		for _, v := range values {
+			fmt.Fprintf(w, "    %-6d %8d %6.1f%%\n", v, dist[v], percent(dist[v], total))
+		}
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return field.Name
+}
+
+// isEnum returns true for named integer types declared by this module.
+func isEnum(t reflect.Type) bool {
+	return t.Name() != "" && t.Name() != "NumberOrString" && strings.HasPrefix(t.PkgPath(), modulePath)
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
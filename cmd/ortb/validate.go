@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/tomlightning/openrtb/v3"
+)
+
+func runValidate(args []string, stdout, stderr io.Writer) error {
+	kind := kindAuto
+
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Var(&kind, "type", "document type: auto, request or response")
+	quiet := fs.Bool("q", false, "only print violations, omit the summary")
+	strict := fs.Bool("strict", false, "reject coerced values, unknown fields and out-of-range enums")
+	report := fs.Bool("report", false, "report coerced values, unknown fields and out-of-range enums without rejecting")
+	categories := fs.Bool("categories", false, "check categories against their taxonomy, if cattax is set")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	mode := openrtb.DecodeLenient
+	if *strict {
+		mode = openrtb.DecodeStrict
+	} else if *report {
+		mode = openrtb.DecodeReportOnly
+	}
+
+	total, invalid := 0, 0
+	err := readRecords(fs.Args(), func(rec *record) error {
+		total++
+
+		doc, rep, err := rec.DecodeWithReport(kind, mode)
+		if *report && rep != nil {
+			for _, v := range rep.Violations {
+				fmt.Fprintf(stdout, "%s: %v\n", rec, v)
+			}
+		}
+
+		var derr *openrtb.DecodeError
+		if errors.As(err, &derr) {
+			invalid++
+			for _, v := range derr.Violations {
+				fmt.Fprintf(stdout, "%s: %v\n", rec, v)
+			}
+			return nil
+		} else if err != nil {
+			invalid++
+			fmt.Fprintf(stdout, "%s: decode: %v\n", rec, err)
+			return nil
+		}
+
+		if errs := violations(doc, *categories); len(errs) != 0 {
+			invalid++
+			for _, err := range errs {
+				fmt.Fprintf(stdout, "%s: %v\n", rec, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !*quiet {
+		fmt.Fprintf(stdout, "%d documents, %d invalid\n", total, invalid)
+	}
+	if invalid != 0 {
+		return errViolations
+	}
+	return nil
+}
+
+// violations returns every violation reported by ValidateAll and, if
+// categories is set, by ValidateCategories.
+func violations(doc interface{}, categories bool) []error {
+	var errs []error
+	switch v := doc.(type) {
+	case *openrtb.BidRequest:
+		errs = appendErrors(errs, v.ValidateAll())
+		if categories {
+			errs = appendErrors(errs, v.ValidateCategories())
+		}
+	case *openrtb.BidResponse:
+		errs = appendErrors(errs, v.ValidateAll())
+		if categories {
+			errs = appendErrors(errs, v.ValidateCategories())
+		}
+	}
+	return errs
+}
+
+// appendErrors appends err, or the errors it joins, to errs.
+func appendErrors(errs []error, err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return append(errs, joined.Unwrap()...)
+	} else if err != nil {
+		return append(errs, err)
+	}
+	return errs
+}
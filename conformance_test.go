@@ -0,0 +1,185 @@
+package openrtb_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+// TestConformance decodes every document in testdata/conformance in strict
+// mode and verifies that re-encoding preserves every member. The documents
+// are modelled on the examples of the specification, they are not verbatim
+// copies of the published 2.5/2.6 examples; those can be dropped into the
+// directory as breq.*.json and bres.*.json.
+func TestConformance(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "conformance", "*.json"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("expected conformance documents")
+	}
+
+	for _, fname := range files {
+		t.Run(filepath.Base(fname), func(t *testing.T) {
+			data, err := os.ReadFile(fname)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			var subject interface{ Validate() error }
+			if strings.HasPrefix(filepath.Base(fname), "breq.") {
+				subject = new(BidRequest)
+			} else {
+				subject = new(BidResponse)
+			}
+
+			if _, err := Decode(data, subject, DecodeStrict); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if err := subject.Validate(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			enc, err := json.Marshal(subject)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			var exp, got interface{}
+			if err := json.Unmarshal(data, &exp); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if err := json.Unmarshal(enc, &got); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			for _, path := range missingMembers(exp, got, "") {
+				t.Errorf("expected %s to be preserved, got %s", path, enc)
+			}
+		})
+	}
+}
+
+// TestConformance_tags verifies that all members use the lower-case names of
+// the specification.
+func TestConformance_tags(t *testing.T) {
+	seen := make(map[reflect.Type]bool)
+	var walk func(reflect.Type)
+	walk = func(rt reflect.Type) {
+		for rt.Kind() == reflect.Ptr || rt.Kind() == reflect.Slice {
+			rt = rt.Elem()
+		}
+		if rt.Kind() != reflect.Struct || seen[rt] {
+			return
+		}
+		seen[rt] = true
+
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name != strings.ToLower(name) {
+				t.Errorf("expected lower-case member name for %s.%s, got %q", rt.Name(), field.Name, name)
+			}
+			walk(field.Type)
+		}
+	}
+	walk(reflect.TypeOf(BidRequest{}))
+	walk(reflect.TypeOf(BidResponse{}))
+}
+
+// TestConformance_compat verifies that documents using the shapes emitted by
+// previous versions of this package still decode.
+func TestConformance_compat(t *testing.T) {
+	var bid *Bid
+	if err := json.Unmarshal([]byte(`{"id":"1","impid":"1","price":1,"apis":7}`), &bid); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := []APIFramework{7}, bid.APIS; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	var format *Format
+	if err := json.Unmarshal([]byte(`{"wratio":16,"hration":9}`), &format); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := (&Format{WidthRatio: 16, HeightRatio: 9}), format; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	var geo *Geo
+	if err := json.Unmarshal([]byte(`{"regionFIPS104":"US06"}`), &geo); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := "US06", geo.RegionFIPS104; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	var res *BidResponse
+	report, err := Decode([]byte(`{"id":"1","seatbid":[{"bid":[{"id":"1","impid":"1","price":1,"apis":7}]}]}`), &res, DecodeLenient)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := []Coercion{{Path: "seatbid[0].bid[0].apis", From: "number", Raw: "7"}}, report.Coercions; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if exp, got := []APIFramework{7}, res.SeatBids[0].Bids[0].APIS; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+// missingMembers returns the paths of non-zero members of exp which are
+// missing or different in got.
+func missingMembers(exp, got interface{}, path string) []string {
+	switch ev := exp.(type) {
+	case map[string]interface{}:
+		gv, _ := got.(map[string]interface{})
+		var paths []string
+		for key, val := range ev {
+			sub := key
+			if path != "" {
+				sub = path + "." + key
+			}
+			if g, ok := gv[key]; ok {
+				paths = append(paths, missingMembers(val, g, sub)...)
+			} else if !isZeroJSON(val) {
+				paths = append(paths, sub)
+			}
+		}
+		return paths
+	case []interface{}:
+		gv, _ := got.([]interface{})
+		if len(ev) != len(gv) {
+			return []string{path}
+		}
+		var paths []string
+		for i := range ev {
+			paths = append(paths, missingMembers(ev[i], gv[i], path+"["+strconv.Itoa(i)+"]")...)
+		}
+		return paths
+	}
+	if !reflect.DeepEqual(exp, got) {
+		return []string{path}
+	}
+	return nil
+}
+
+func isZeroJSON(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	}
+	return false
+}
@@ -0,0 +1,410 @@
+package openrtb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomlightning/openrtb/v3/internal/jsonfields"
+)
+
+// Decode violations.
+var (
+	ErrCoercion     = errors.New("openrtb: value requires coercion")
+	ErrUnknownField = errors.New("openrtb: unknown field")
+	ErrTypeMismatch = errors.New("openrtb: type mismatch")
+	ErrEnumRange    = errors.New("openrtb: enum value out of range")
+)
+
+// DecodeMode controls how Decode treats values that do not have the JSON type
+// required by the standard.
+type DecodeMode int8
+
+// DecodeMode options.
+const (
+	// DecodeLenient accepts quoted numbers, booleans and floats with a zero
+	// fraction for integer flags and enums and coerces them. Unknown fields and
+	// out-of-range enums are reported but accepted.
+	DecodeLenient DecodeMode = iota
+	// DecodeStrict rejects documents with any violation, including values
+	// that would require a coercion.
+	DecodeStrict
+	// DecodeReportOnly behaves like DecodeLenient, but skips values with
+	// mismatching types instead of failing, so that all violations of a
+	// document can be reported at once.
+	DecodeReportOnly
+)
+
+// ViolationKind identifies the kind of a Violation.
+type ViolationKind int8
+
+// ViolationKind options.
+const (
+	ViolationCoercion     ViolationKind = 1 // Value needed a coercion
+	ViolationUnknownField ViolationKind = 2 // Field is not part of the standard
+	ViolationTypeMismatch ViolationKind = 3 // Value has the wrong JSON type
+	ViolationEnumRange    ViolationKind = 4 // Enum value is outside of its defined range
+)
+
+var violationErrs = map[ViolationKind]error{
+	ViolationCoercion:     ErrCoercion,
+	ViolationUnknownField: ErrUnknownField,
+	ViolationTypeMismatch: ErrTypeMismatch,
+	ViolationEnumRange:    ErrEnumRange,
+}
+
+// Violation describes a part of a document that does not conform to the standard.
+type Violation struct {
+	Path string        // JSON path of the value, e.g. "imp[0].video.protocols[1]"
+	Kind ViolationKind // Kind of violation
+	Raw  string        // Original value as encoded in the input
+}
+
+// Err returns the sentinel error of the violation kind.
+func (v Violation) Err() error {
+	return violationErrs[v.Kind]
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s at %s: %s", v.Err().Error(), v.Path, v.Raw)
+}
+
+// DecodeError is returned by Decode in strict mode. It lists all violations.
+type DecodeError struct {
+	Violations []Violation
+}
+
+func (e *DecodeError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap allows to match specific violation kinds with errors.Is.
+func (e *DecodeError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		errs = append(errs, v.Err())
+	}
+	return errs
+}
+
+// Coercion records a single value that was coerced during decoding.
+type Coercion struct {
+	Path string // JSON path of the value, e.g. "imp[0].instl"
+	From string // JSON type of the original value: "string", "bool" or "number"
+	Raw  string // Original value as encoded in the input
+}
+
+// UnknownField is a JSON member that is not modelled by this package.
+type UnknownField struct {
+	Parent    string          // JSON path of the parent object, e.g. "imp[0].video"
+	ParentIDs []string        // "id" attributes of the array elements along Parent, empty for elements without one
+	Key       string          // Name of the member
+	Value     json.RawMessage // Original value
+}
+
+// DecodeReport describes the outcome of Decode.
+type DecodeReport struct {
+	Coercions  []Coercion     // Coercions applied, ordered by path
+	Violations []Violation    // Violations found, ordered by path
+	Unknown    []UnknownField // Unknown fields, can be restored with MarshalLossless
+}
+
+// Decode decodes data into v, which must be a pointer to one of the structs of
+// this package. Unlike json.Unmarshal, it tolerates the common encodings of
+// integer flags and enums sent by exchanges, such as "instl":"1", "dnt":true
+// or "w":300.0, and records every coercion in the returned report.
+// Unknown fields, type mismatches and out-of-range enum values are reported as
+// violations. In DecodeStrict mode, any violation results in a *DecodeError.
+func Decode(data []byte, v interface{}, mode DecodeMode) (*DecodeReport, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, fmt.Errorf("openrtb: cannot decode into %T", v)
+	}
+
+	tree, err := decodeTree(data)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &decodeState{mode: mode, report: new(DecodeReport)}
+	tree, _ = st.walk(tree, rv.Type().Elem(), "")
+	if mode == DecodeStrict && len(st.report.Violations) != 0 {
+		return st.report, &DecodeError{Violations: st.report.Violations}
+	}
+
+	if st.changed {
+		if data, err = json.Marshal(tree); err != nil {
+			return st.report, err
+		}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return st.report, err
+	}
+	return st.report, nil
+}
+
+type decodeState struct {
+	mode    DecodeMode
+	report  *DecodeReport
+	changed bool
+	ids     []string // "id" attributes of the array elements being walked
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func (s *decodeState) violate(kind ViolationKind, path string, node interface{}) {
+	s.report.Violations = append(s.report.Violations, Violation{Path: path, Kind: kind, Raw: rawString(node)})
+}
+
+func (s *decodeState) unknown(parent, key string, node interface{}) {
+	raw := rawString(node)
+	s.report.Violations = append(s.report.Violations, Violation{Path: joinPath(parent, key), Kind: ViolationUnknownField, Raw: raw})
+	s.report.Unknown = append(s.report.Unknown, UnknownField{
+		Parent:    parent,
+		ParentIDs: append([]string(nil), s.ids...),
+		Key:       key,
+		Value:     json.RawMessage(raw),
+	})
+}
+
+// mismatch records a type mismatch and reports whether the node should be kept.
+func (s *decodeState) mismatch(path string, node interface{}) (interface{}, bool) {
+	s.violate(ViolationTypeMismatch, path, node)
+	if s.mode == DecodeReportOnly {
+		s.changed = true
+		return nil, false
+	}
+	return node, true
+}
+
+// legacyKeys maps keys which previous versions of this package emitted, and
+// which are still accepted by UnmarshalJSON, to their current names.
+var legacyKeys = map[reflect.Type]map[string]string{
+	reflect.TypeOf(Format{}): {"hration": "hratio"},
+}
+
+// walk traverses node, which is expected to decode into t. It returns the
+// (possibly coerced) node and false if the node should be dropped.
+func (s *decodeState) walk(node interface{}, t reflect.Type, path string) (interface{}, bool) {
+	if node == nil {
+		return nil, true
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := node.(map[string]interface{})
+		if !ok {
+			return s.mismatch(path, node)
+		}
+
+		for _, key := range sortedMapKeys(obj) {
+			sub := joinPath(path, key)
+
+			field, ok := jsonfields.Lookup(t, key)
+			if legacy, isLegacy := legacyKeys[t][key]; !ok && isLegacy {
+				field, ok = jsonfields.Lookup(t, legacy)
+			}
+			if !ok {
+				s.unknown(path, key, obj[key])
+				continue
+			}
+
+			if val, ok := s.walk(obj[key], field.Type, sub); ok {
+				obj[key] = val
+			} else {
+				delete(obj, key)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return node, true
+		}
+
+		arr, ok := node.([]interface{})
+		if !ok {
+			if arr, ok = s.coerceArray(node, t, path); !ok {
+				return s.mismatch(path, node)
+			}
+		}
+
+		kept := arr[:0]
+		for i, el := range arr {
+			s.ids = append(s.ids, elementID(el))
+			if val, ok := s.walk(el, t.Elem(), path+"["+strconv.Itoa(i)+"]"); ok {
+				kept = append(kept, val)
+			}
+			s.ids = s.ids[:len(s.ids)-1]
+		}
+		return kept, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		node = s.coerceInt(node, t, path)
+
+		num, ok := node.(json.Number)
+		if !ok {
+			return s.mismatch(path, node)
+		}
+		n, err := num.Int64()
+		if err != nil || reflect.Zero(t).OverflowInt(n) {
+			return s.mismatch(path, node)
+		}
+		if !enumInRange(t, n, strings.HasSuffix(path, "]")) {
+			s.violate(ViolationEnumRange, path, node)
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := node.(json.Number); !ok {
+			return s.mismatch(path, node)
+		}
+	case reflect.String:
+		if _, ok := node.(string); ok {
+			return node, true
+		}
+		if _, ok := node.(json.Number); ok && reflect.PtrTo(t).Implements(unmarshalerType) {
+			return node, true
+		}
+		return s.mismatch(path, node)
+	}
+	return node, true
+}
+
+// coerceInt attempts to coerce node into an integer, returns the original node if
+// no coercion is necessary or possible.
+func (s *decodeState) coerceInt(node interface{}, t reflect.Type, path string) interface{} {
+	var (
+		n    int64
+		from string
+		raw  string
+	)
+
+	switch v := node.(type) {
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return node
+		}
+
+		f, err := v.Float64()
+		if err != nil || !isIntegral(f) {
+			return node
+		}
+		n, from, raw = int64(f), "number", v.String()
+	case string:
+		x, ok := parseLenientInt(v)
+		if !ok {
+			return node
+		}
+		n, from, raw = x, "string", strconv.Quote(v)
+	case bool:
+		if v {
+			n = 1
+		}
+		from, raw = "bool", strconv.FormatBool(v)
+	default:
+		return node
+	}
+
+	if reflect.Zero(t).OverflowInt(n) {
+		return node
+	}
+
+	s.report.Coercions = append(s.report.Coercions, Coercion{Path: path, From: from, Raw: raw})
+	if s.mode == DecodeStrict {
+		s.report.Violations = append(s.report.Violations, Violation{Path: path, Kind: ViolationCoercion, Raw: raw})
+	}
+
+	s.changed = true
+	return json.Number(strconv.FormatInt(n, 10))
+}
+
+// coerceArray attempts to wrap a single integer into an array of integers, as
+// sent for fields which were scalars in earlier versions of the standard.
+func (s *decodeState) coerceArray(node interface{}, t reflect.Type, path string) ([]interface{}, bool) {
+	num, ok := node.(json.Number)
+	if !ok {
+		return nil, false
+	}
+	switch t.Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	default:
+		return nil, false
+	}
+
+	s.report.Coercions = append(s.report.Coercions, Coercion{Path: path, From: "number", Raw: num.String()})
+	if s.mode == DecodeStrict {
+		s.report.Violations = append(s.report.Violations, Violation{Path: path, Kind: ViolationCoercion, Raw: num.String()})
+	}
+
+	s.changed = true
+	return []interface{}{node}, true
+}
+
+func rawString(node interface{}) string {
+	b, err := json.Marshal(node)
+	if err != nil {
+		return fmt.Sprint(node)
+	}
+	return string(b)
+}
+
+func parseLenientInt(s string) (int64, bool) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, true
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && isIntegral(f) {
+		return int64(f), true
+	}
+	return 0, false
+}
+
+func isIntegral(f float64) bool {
+	return f == math.Trunc(f) && f >= math.MinInt64 && f <= math.MaxInt64
+}
+
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func sortedMapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// --------------------------------------------------------------------
+
+// enum is implemented by the enum types of the standard.
+type enum interface {
+	IsValid() bool
+}
+
+var enumType = reflect.TypeOf((*enum)(nil)).Elem()
+
+// enumInRange returns true if n is a valid value for t. Outside of arrays, zero
+// values are always accepted and treated as "not set".
+func enumInRange(t reflect.Type, n int64, inArray bool) bool {
+	if !t.Implements(enumType) || (n == 0 && !inArray) {
+		return true
+	}
+
+	v := reflect.New(t).Elem()
+	v.SetInt(n)
+	return v.Interface().(enum).IsValid()
+}
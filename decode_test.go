@@ -0,0 +1,147 @@
+package openrtb_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestDecode(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "breq.lenient.json"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var subject *BidRequest
+	report, err := Decode(data, &subject, DecodeLenient)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := &BidRequest{
+		ID:          "1234534625254",
+		AuctionType: 2,
+		Test:        1,
+		TimeMax:     120,
+		Impressions: []Impression{
+			{ID: "1", Interstitial: 1, Secure: 1, Banner: &Banner{Width: 300, Height: 250, Position: AdPositionAboveFold}},
+		},
+		Device: &Device{
+			UA:         "Mozilla/5.0",
+			IP:         "64.124.253.1",
+			DNT:        1,
+			DeviceType: DeviceTypePhone,
+			JS:         1,
+		},
+	}
+	if got := subject; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	expCoercions := []Coercion{
+		{Path: "at", From: "string", Raw: `"2"`},
+		{Path: "device.devicetype", From: "string", Raw: `"4"`},
+		{Path: "device.dnt", From: "bool", Raw: `true`},
+		{Path: "device.lmt", From: "string", Raw: `"0"`},
+		{Path: "imp[0].banner.h", From: "number", Raw: `250.0`},
+		{Path: "imp[0].banner.pos", From: "string", Raw: `"1"`},
+		{Path: "imp[0].banner.w", From: "string", Raw: `"300"`},
+		{Path: "imp[0].instl", From: "string", Raw: `"1"`},
+		{Path: "imp[0].secure", From: "bool", Raw: `true`},
+		{Path: "test", From: "string", Raw: `"1"`},
+		{Path: "tmax", From: "number", Raw: `120.0`},
+	}
+	if got := report.Coercions; !reflect.DeepEqual(expCoercions, got) {
+		t.Errorf("expected %+v, got %+v", expCoercions, got)
+	}
+}
+
+func TestDecode_strict(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "breq.lenient.json"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var subject *BidRequest
+	if _, err := Decode(data, &subject, DecodeStrict); !errors.Is(err, ErrCoercion) {
+		t.Fatalf("expected %v, got %v", ErrCoercion, err)
+	}
+
+	report, err := Decode([]byte(`{"id":"1","imp":[{"id":"1","instl":1}],"at":2}`), &subject, DecodeStrict)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := 0, len(report.Coercions); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := int8(1), subject.Impressions[0].Interstitial; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestDecode_invalid(t *testing.T) {
+	var subject BidRequest
+	for _, data := range []string{
+		`{"id":"1","at":"first"}`,
+		`{"id":"1","at":1.5}`,
+		`{"id":"1","test":"300"}`,
+	} {
+		if _, err := Decode([]byte(data), &subject, DecodeLenient); err == nil {
+			t.Errorf("expected error for %s", data)
+		}
+	}
+}
+
+func TestDecode_violations(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "breq.violations.json"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expViolations := []Violation{
+		{Path: "custom", Kind: ViolationUnknownField, Raw: `{"nested":true}`},
+		{Path: "device.devicetype", Kind: ViolationEnumRange, Raw: `9`},
+		{Path: "imp[0].tagid", Kind: ViolationTypeMismatch, Raw: `42`},
+		{Path: "imp[0].video.foo", Kind: ViolationUnknownField, Raw: `"bar"`},
+		{Path: "imp[0].video.protocols[1]", Kind: ViolationEnumRange, Raw: `11`},
+		{Path: "imp[0].video.w", Kind: ViolationTypeMismatch, Raw: `"wide"`},
+	}
+
+	var subject *BidRequest
+	report, err := Decode(data, &subject, DecodeReportOnly)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := report.Violations; !reflect.DeepEqual(expViolations, got) {
+		t.Errorf("expected %+v, got %+v", expViolations, got)
+	}
+	if exp, got := []Protocol{ProtocolVAST2, Protocol(11)}, subject.Impressions[0].Video.Protocols; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := "", subject.Impressions[0].TagID; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	subject = nil
+	report, err = Decode(data, &subject, DecodeStrict)
+	if !errors.Is(err, ErrUnknownField) || !errors.Is(err, ErrTypeMismatch) || !errors.Is(err, ErrEnumRange) {
+		t.Fatalf("expected violation errors, got %v", err)
+	}
+	if errors.Is(err, ErrCoercion) {
+		t.Errorf("expected no coercion errors, got %v", err)
+	}
+	if got := report.Violations; !reflect.DeepEqual(expViolations, got) {
+		t.Errorf("expected %+v, got %+v", expViolations, got)
+	}
+	if subject != nil {
+		t.Errorf("expected no result, got %+v", subject)
+	}
+
+	if _, err := Decode(data, &subject, DecodeLenient); err == nil {
+		t.Errorf("expected type mismatch error")
+	}
+}
@@ -30,10 +30,10 @@ type Device struct {
 	Sua          *UserAgent      `json:"sua,omitempty"`            // Structured User agent. It's more accurate than UA
 	Geo          *Geo            `json:"geo,omitempty"`            // Location of the device assumed to be the user’s current location
 	PixelRatio   float64         `json:"pxratio,omitempty"`        // The ratio of physical pixels to device independent pixels.
-	Height       int16           `json:"h,omitempty"`              // Physical height of the screen in pixels.
-	Width        int16           `json:"w,omitempty"`              // Physical width of the screen in pixels.
-	PPI          int32           `json:"ppi,omitempty"`            // Screen size as pixels per linear inch.
-	GeoFetch     int16           `json:"geofetch,omitempty"`       // Indicates if the geolocation API will be available to JavaScript code running in the banner,
+	Height       int             `json:"h,omitempty"`              // Physical height of the screen in pixels.
+	Width        int             `json:"w,omitempty"`              // Physical width of the screen in pixels.
+	PPI          int             `json:"ppi,omitempty"`            // Screen size as pixels per linear inch.
+	GeoFetch     int             `json:"geofetch,omitempty"`       // Indicates if the geolocation API will be available to JavaScript code running in the banner,
 	DNT          int8            `json:"dnt"`                      // "1": Do not track
 	LMT          int8            `json:"lmt"`                      // "1": Limit Ad Tracking
 	DeviceType   DeviceType      `json:"devicetype,omitempty"`     // The general type of device.
@@ -0,0 +1,144 @@
+package openrtb
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Change describes a single difference between two documents. Values are
+// generic JSON values, as produced by decoding into an interface{}.
+type Change struct {
+	Path string      // JSON path, e.g. "imp[id=1].video.w" or "bcat[2]"
+	Old  interface{} // Old value, nil if the value was added
+	New  interface{} // New value, nil if the value was removed
+}
+
+func (c Change) String() string {
+	switch {
+	case c.Old == nil:
+		return "+ " + c.Path + ": " + rawString(c.New)
+	case c.New == nil:
+		return "- " + c.Path + ": " + rawString(c.Old)
+	}
+	return "~ " + c.Path + ": " + rawString(c.Old) + " -> " + rawString(c.New)
+}
+
+// Changes is a list of changes.
+type Changes []Change
+
+// String renders the changes in a human-readable form, one per line.
+func (cs Changes) String() string {
+	var b strings.Builder
+	for _, c := range cs {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Diff returns the structural differences between two documents, typically
+// two BidRequests or two BidResponses. Documents are compared by their JSON
+// representation, so that ext contents are compared semantically rather than
+// bytewise. Elements of arrays of objects with unique "id" attributes, such
+// as impressions, seatbids or bids, are matched by ID rather than by position.
+func Diff(a, b interface{}) (Changes, error) {
+	ta, err := toTree(a)
+	if err != nil {
+		return nil, err
+	}
+	tb, err := toTree(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes Changes
+	diffTree(ta, tb, "", &changes)
+	return changes, nil
+}
+
+func diffTree(a, b interface{}, path string, changes *Changes) {
+	switch x := a.(type) {
+	case map[string]interface{}:
+		if y, ok := b.(map[string]interface{}); ok {
+			diffObject(x, y, path, changes)
+			return
+		}
+	case []interface{}:
+		if y, ok := b.([]interface{}); ok {
+			diffArray(x, y, path, changes)
+			return
+		}
+	}
+
+	if !treeEqual(a, b) {
+		*changes = append(*changes, Change{Path: path, Old: a, New: b})
+	}
+}
+
+func diffObject(a, b map[string]interface{}, path string, changes *Changes) {
+	keys := sortedMapKeys(a)
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		diffTree(a[key], b[key], joinPath(path, key), changes)
+	}
+}
+
+func diffArray(a, b []interface{}, path string, changes *Changes) {
+	ia, oka := indexByID(a)
+	ib, okb := indexByID(b)
+	if !oka || !okb {
+		for i := 0; i < len(a) || i < len(b); i++ {
+			var x, y interface{}
+			if i < len(a) {
+				x = a[i]
+			}
+			if i < len(b) {
+				y = b[i]
+			}
+			diffTree(x, y, path+"["+strconv.Itoa(i)+"]", changes)
+		}
+		return
+	}
+
+	for _, el := range a {
+		id := el.(map[string]interface{})["id"].(string)
+		diffTree(el, ib[id], path+"[id="+id+"]", changes)
+	}
+	for _, el := range b {
+		id := el.(map[string]interface{})["id"].(string)
+		if _, ok := ia[id]; !ok {
+			diffTree(nil, el, path+"[id="+id+"]", changes)
+		}
+	}
+}
+
+// indexByID indexes an array of objects by their unique "id" attributes.
+func indexByID(arr []interface{}) (map[string]interface{}, bool) {
+	if len(arr) == 0 {
+		return nil, true
+	}
+
+	index := make(map[string]interface{}, len(arr))
+	for _, el := range arr {
+		obj, ok := el.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		id, ok := obj["id"].(string)
+		if !ok {
+			return nil, false
+		}
+		if _, dup := index[id]; dup {
+			return nil, false
+		}
+		index[id] = el
+	}
+	return index, true
+}
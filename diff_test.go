@@ -0,0 +1,63 @@
+package openrtb_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestDiff(t *testing.T) {
+	var a *BidRequest
+	if err := fixture("breq.video", &a); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	b := a.Clone()
+	if changes, err := Diff(a, b); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	} else if len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v", changes)
+	}
+
+	b.TimeMax = 250
+	b.Impressions = append(b.Impressions[1:], Impression{ID: "4", Secure: 1})
+	b.Impressions[0].Video.Width = 320
+	b.Impressions[0].PMP.Deals[0].Ext = json.RawMessage(`{ "wadvs": [], "priority" : 1.0 }`)
+	b.Impressions[1].Video.Protocols = append(b.Impressions[1].Video.Protocols, ProtocolVAST4)
+	b.User.BuyerUID = ""
+
+	changes, err := Diff(a, b)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := `- imp[id=1]: {"id":"1","instl":0,"pmp":{"deals":[{"at":1,"bidfloor":5.3,"ext":{"priority":1,"wadvs":[]},"id":"1452f.eadb4.7aaa"}],"private_auction":1},"secure":0,"video":{"api":[1,2],"battr":[13,14],"boxingallowed":1,"delivery":[2],"h":480,"linearity":1,"maxbitrate":1500,"maxduration":30,"mimes":["video/x-flv","video/mp4","application/x-shockwave-flash","application/javascript"],"minbitrate":300,"minduration":5,"playbackmethod":[1],"pos":1,"protocols":[2,3],"sequence":1,"w":640}}
+~ imp[id=2].video.w: 640 -> 320
++ imp[id=3].video.protocols[2]: 7
++ imp[id=4]: {"id":"4","instl":0,"secure":1}
+~ tmax: 120 -> 250
+- user.buyeruid: "545678765467876567898765678987654"
+`
+	if got := changes.String(); exp != got {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, got)
+	}
+}
+
+func TestDiff_positional(t *testing.T) {
+	a := &BidResponse{ID: "1", SeatBids: []SeatBid{{Seat: "a"}, {Seat: "b"}}}
+	b := &BidResponse{ID: "1", SeatBids: []SeatBid{{Seat: "b"}}}
+
+	changes, err := Diff(a, b)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := `~ seatbid[0].seat: "a" -> "b"
+- seatbid[1]: {"bid":null,"seat":"b"}
+`
+	if got := changes.String(); exp != got {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, got)
+	}
+}
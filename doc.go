@@ -3,3 +3,6 @@ Package openrtb implements a parser (with optional validation) and a generator f
 OpenRTB 2.x requests and responses.
 */
 package openrtb
+
+//go:generate go run ./internal/cmd/clonegen
+//go:generate go run ./internal/cmd/enumgen
@@ -0,0 +1,39 @@
+package openrtb
+
+import "fmt"
+
+// Enum is implemented by the enum types of this package and its
+// subpackages.
+type Enum interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+	fmt.Stringer
+	Text() string
+	IsValid() bool
+}
+
+// Named wraps an enum value to encode it by name rather than by number, e.g.
+// in logs, reports or configuration files:
+//
+//	json.Marshal(openrtb.Named[openrtb.Protocol]{Value: openrtb.ProtocolVAST4}) // "VAST4"
+//
+// The enum types themselves are always encoded as numbers.
+type Named[T Enum] struct {
+	Value T
+}
+
+// String returns the name of the value.
+func (n Named[T]) String() string { return n.Value.String() }
+
+// MarshalText implements encoding.TextMarshaler.
+func (n Named[T]) MarshalText() ([]byte, error) {
+	return []byte(n.Value.Text()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (n *Named[T]) UnmarshalText(text []byte) error {
+	x, ok := any(&n.Value).(interface{ Set(string) error })
+	if !ok {
+		return fmt.Errorf("openrtb: %T cannot be parsed", n.Value)
+	}
+	return x.Set(string(text))
+}
@@ -0,0 +1,1331 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package openrtb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var apiFrameworkNames = map[APIFramework]string{
+	APIFrameworkUnknown: "Unknown",
+	APIFrameworkVPAID1:  "VPAID1",
+	APIFrameworkVPAID2:  "VPAID2",
+	APIFrameworkMRAID1:  "MRAID1",
+	APIFrameworkORMMA:   "ORMMA",
+	APIFrameworkMRAID2:  "MRAID2",
+	APIFrameworkMRAID3:  "MRAID3",
+	APIFrameworkOMID:    "OMID",
+	APIFrameworkSIMID1:  "SIMID1",
+	APIFrameworkSIMID11: "SIMID11",
+}
+
+// String returns the name of the APIFramework.
+func (x APIFramework) String() string {
+	return formatEnum(apiFrameworkNames, "APIFramework", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x APIFramework) IsValid() bool {
+	return x >= APIFrameworkVPAID1 && x <= APIFrameworkSIMID11
+}
+
+// APIFrameworkValues returns all valid APIFramework values.
+func APIFrameworkValues() []APIFramework {
+	return []APIFramework{
+		APIFrameworkVPAID1,
+		APIFrameworkVPAID2,
+		APIFrameworkMRAID1,
+		APIFrameworkORMMA,
+		APIFrameworkMRAID2,
+		APIFrameworkMRAID3,
+		APIFrameworkOMID,
+		APIFrameworkSIMID1,
+		APIFrameworkSIMID11,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseAPIFramework.
+func (x APIFramework) Text() string {
+	return enumText(apiFrameworkNames, x, true)
+}
+
+// ParseAPIFramework returns the APIFramework for a case-insensitive name or a numeric value.
+func ParseAPIFramework(s string) (APIFramework, error) {
+	return parseEnum(apiFrameworkNames, "APIFramework", s, true)
+}
+
+// Set parses s into x, see ParseAPIFramework. It implements flag.Value.
+func (x *APIFramework) Set(s string) (err error) {
+	*x, err = ParseAPIFramework(s)
+	return err
+}
+
+var adPositionNames = map[AdPosition]string{
+	AdPositionUnknown:      "Unknown",
+	AdPositionAboveFold:    "AboveFold",
+	AdPositionMaybeVisible: "MaybeVisible",
+	AdPositionBelowFold:    "BelowFold",
+	AdPositionHeader:       "Header",
+	AdPositionFooter:       "Footer",
+	AdPositionSidebar:      "Sidebar",
+	AdPositionFullscreen:   "Fullscreen",
+}
+
+// String returns the name of the AdPosition.
+func (x AdPosition) String() string {
+	return formatEnum(adPositionNames, "AdPosition", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x AdPosition) IsValid() bool {
+	return x >= AdPositionUnknown && x <= AdPositionFullscreen
+}
+
+// AdPositionValues returns all valid AdPosition values.
+func AdPositionValues() []AdPosition {
+	return []AdPosition{
+		AdPositionUnknown,
+		AdPositionAboveFold,
+		AdPositionMaybeVisible,
+		AdPositionBelowFold,
+		AdPositionHeader,
+		AdPositionFooter,
+		AdPositionSidebar,
+		AdPositionFullscreen,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseAdPosition.
+func (x AdPosition) Text() string {
+	return enumText(adPositionNames, x, false)
+}
+
+// ParseAdPosition returns the AdPosition for a case-insensitive name or a numeric value.
+func ParseAdPosition(s string) (AdPosition, error) {
+	return parseEnum(adPositionNames, "AdPosition", s, false)
+}
+
+// Set parses s into x, see ParseAdPosition. It implements flag.Value.
+func (x *AdPosition) Set(s string) (err error) {
+	*x, err = ParseAdPosition(s)
+	return err
+}
+
+var bannerTypeNames = map[BannerType]string{
+	BannerTypeXHTMLText: "XHTMLText",
+	BannerTypeXHTML:     "XHTML",
+	BannerTypeJS:        "JS",
+	BannerTypeFrame:     "Frame",
+}
+
+// String returns the name of the BannerType.
+func (x BannerType) String() string {
+	return formatEnum(bannerTypeNames, "BannerType", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x BannerType) IsValid() bool {
+	return x >= BannerTypeXHTMLText && x <= BannerTypeFrame
+}
+
+// BannerTypeValues returns all valid BannerType values.
+func BannerTypeValues() []BannerType {
+	return []BannerType{
+		BannerTypeXHTMLText,
+		BannerTypeXHTML,
+		BannerTypeJS,
+		BannerTypeFrame,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseBannerType.
+func (x BannerType) Text() string {
+	return enumText(bannerTypeNames, x, false)
+}
+
+// ParseBannerType returns the BannerType for a case-insensitive name or a numeric value.
+func ParseBannerType(s string) (BannerType, error) {
+	return parseEnum(bannerTypeNames, "BannerType", s, false)
+}
+
+// Set parses s into x, see ParseBannerType. It implements flag.Value.
+func (x *BannerType) Set(s string) (err error) {
+	*x, err = ParseBannerType(s)
+	return err
+}
+
+var categoryTaxonomyNames = map[CategoryTaxonomy]string{
+	CategoryTaxonomyIABContent1:   "IABContent1",
+	CategoryTaxonomyIABContent2:   "IABContent2",
+	CategoryTaxonomyIABProduct1:   "IABProduct1",
+	CategoryTaxonomyIABAudience11: "IABAudience11",
+	CategoryTaxonomyIABContent21:  "IABContent21",
+	CategoryTaxonomyIABContent22:  "IABContent22",
+	CategoryTaxonomyIABContent3:   "IABContent3",
+	CategoryTaxonomyIABProduct2:   "IABProduct2",
+}
+
+// String returns the name of the CategoryTaxonomy.
+func (x CategoryTaxonomy) String() string {
+	return formatEnum(categoryTaxonomyNames, "CategoryTaxonomy", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x CategoryTaxonomy) IsValid() bool {
+	return x >= CategoryTaxonomyIABContent1 && x <= CategoryTaxonomyIABProduct2
+}
+
+// CategoryTaxonomyValues returns all valid CategoryTaxonomy values.
+func CategoryTaxonomyValues() []CategoryTaxonomy {
+	return []CategoryTaxonomy{
+		CategoryTaxonomyIABContent1,
+		CategoryTaxonomyIABContent2,
+		CategoryTaxonomyIABProduct1,
+		CategoryTaxonomyIABAudience11,
+		CategoryTaxonomyIABContent21,
+		CategoryTaxonomyIABContent22,
+		CategoryTaxonomyIABContent3,
+		CategoryTaxonomyIABProduct2,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseCategoryTaxonomy.
+func (x CategoryTaxonomy) Text() string {
+	return enumText(categoryTaxonomyNames, x, false)
+}
+
+// ParseCategoryTaxonomy returns the CategoryTaxonomy for a case-insensitive name or a numeric value.
+func ParseCategoryTaxonomy(s string) (CategoryTaxonomy, error) {
+	return parseEnum(categoryTaxonomyNames, "CategoryTaxonomy", s, false)
+}
+
+// Set parses s into x, see ParseCategoryTaxonomy. It implements flag.Value.
+func (x *CategoryTaxonomy) Set(s string) (err error) {
+	*x, err = ParseCategoryTaxonomy(s)
+	return err
+}
+
+var companionTypeNames = map[CompanionType]string{
+	CompanionTypeUnknown: "Unknown",
+	CompanionTypeStatic:  "Static",
+	CompanionTypeHTML:    "HTML",
+	CompanionTypeIFrame:  "IFrame",
+}
+
+// String returns the name of the CompanionType.
+func (x CompanionType) String() string {
+	return formatEnum(companionTypeNames, "CompanionType", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x CompanionType) IsValid() bool {
+	return x >= CompanionTypeStatic && x <= CompanionTypeIFrame
+}
+
+// CompanionTypeValues returns all valid CompanionType values.
+func CompanionTypeValues() []CompanionType {
+	return []CompanionType{
+		CompanionTypeStatic,
+		CompanionTypeHTML,
+		CompanionTypeIFrame,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseCompanionType.
+func (x CompanionType) Text() string {
+	return enumText(companionTypeNames, x, true)
+}
+
+// ParseCompanionType returns the CompanionType for a case-insensitive name or a numeric value.
+func ParseCompanionType(s string) (CompanionType, error) {
+	return parseEnum(companionTypeNames, "CompanionType", s, true)
+}
+
+// Set parses s into x, see ParseCompanionType. It implements flag.Value.
+func (x *CompanionType) Set(s string) (err error) {
+	*x, err = ParseCompanionType(s)
+	return err
+}
+
+var connTypeNames = map[ConnType]string{
+	ConnTypeUnknown:  "Unknown",
+	ConnTypeEthernet: "Ethernet",
+	ConnTypeWIFI:     "WIFI",
+	ConnTypeCell:     "Cell",
+	ConnTypeCell2G:   "Cell2G",
+	ConnTypeCell3G:   "Cell3G",
+	ConnTypeCell4G:   "Cell4G",
+}
+
+// String returns the name of the ConnType.
+func (x ConnType) String() string {
+	return formatEnum(connTypeNames, "ConnType", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x ConnType) IsValid() bool {
+	return x >= ConnTypeUnknown && x <= ConnTypeCell4G
+}
+
+// ConnTypeValues returns all valid ConnType values.
+func ConnTypeValues() []ConnType {
+	return []ConnType{
+		ConnTypeUnknown,
+		ConnTypeEthernet,
+		ConnTypeWIFI,
+		ConnTypeCell,
+		ConnTypeCell2G,
+		ConnTypeCell3G,
+		ConnTypeCell4G,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseConnType.
+func (x ConnType) Text() string {
+	return enumText(connTypeNames, x, false)
+}
+
+// ParseConnType returns the ConnType for a case-insensitive name or a numeric value.
+func ParseConnType(s string) (ConnType, error) {
+	return parseEnum(connTypeNames, "ConnType", s, false)
+}
+
+// Set parses s into x, see ParseConnType. It implements flag.Value.
+func (x *ConnType) Set(s string) (err error) {
+	*x, err = ParseConnType(s)
+	return err
+}
+
+var contentContextNames = map[ContentContext]string{
+	ContentContextVideo:       "Video",
+	ContentContextGame:        "Game",
+	ContentContextMusic:       "Music",
+	ContentContextApplication: "Application",
+	ContentContextText:        "Text",
+	ContentContextOther:       "Other",
+	ContentContextUnknown:     "Unknown",
+}
+
+// String returns the name of the ContentContext.
+func (x ContentContext) String() string {
+	return formatEnum(contentContextNames, "ContentContext", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x ContentContext) IsValid() bool {
+	return x >= ContentContextVideo && x <= ContentContextUnknown
+}
+
+// ContentContextValues returns all valid ContentContext values.
+func ContentContextValues() []ContentContext {
+	return []ContentContext{
+		ContentContextVideo,
+		ContentContextGame,
+		ContentContextMusic,
+		ContentContextApplication,
+		ContentContextText,
+		ContentContextOther,
+		ContentContextUnknown,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseContentContext.
+func (x ContentContext) Text() string {
+	return enumText(contentContextNames, x, false)
+}
+
+// ParseContentContext returns the ContentContext for a case-insensitive name or a numeric value.
+func ParseContentContext(s string) (ContentContext, error) {
+	return parseEnum(contentContextNames, "ContentContext", s, false)
+}
+
+// Set parses s into x, see ParseContentContext. It implements flag.Value.
+func (x *ContentContext) Set(s string) (err error) {
+	*x, err = ParseContentContext(s)
+	return err
+}
+
+var contentDeliveryNames = map[ContentDelivery]string{
+	ContentDeliveryUnknown:     "Unknown",
+	ContentDeliveryStreaming:   "Streaming",
+	ContentDeliveryProgressive: "Progressive",
+	ContentDeliveryDownload:    "Download",
+}
+
+// String returns the name of the ContentDelivery.
+func (x ContentDelivery) String() string {
+	return formatEnum(contentDeliveryNames, "ContentDelivery", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x ContentDelivery) IsValid() bool {
+	return x >= ContentDeliveryStreaming && x <= ContentDeliveryDownload
+}
+
+// ContentDeliveryValues returns all valid ContentDelivery values.
+func ContentDeliveryValues() []ContentDelivery {
+	return []ContentDelivery{
+		ContentDeliveryStreaming,
+		ContentDeliveryProgressive,
+		ContentDeliveryDownload,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseContentDelivery.
+func (x ContentDelivery) Text() string {
+	return enumText(contentDeliveryNames, x, true)
+}
+
+// ParseContentDelivery returns the ContentDelivery for a case-insensitive name or a numeric value.
+func ParseContentDelivery(s string) (ContentDelivery, error) {
+	return parseEnum(contentDeliveryNames, "ContentDelivery", s, true)
+}
+
+// Set parses s into x, see ParseContentDelivery. It implements flag.Value.
+func (x *ContentDelivery) Set(s string) (err error) {
+	*x, err = ParseContentDelivery(s)
+	return err
+}
+
+var creativeAttributeNames = map[CreativeAttribute]string{
+	CreativeAttributeAudioAdAutoPlay:                 "AudioAdAutoPlay",
+	CreativeAttributeAudioAdUserInitiated:            "AudioAdUserInitiated",
+	CreativeAttributeExpandableAuto:                  "ExpandableAuto",
+	CreativeAttributeExpandableUserInitiatedClick:    "ExpandableUserInitiatedClick",
+	CreativeAttributeExpandableUserInitiatedRollover: "ExpandableUserInitiatedRollover",
+	CreativeAttributeInBannerVideoAdAutoPlay:         "InBannerVideoAdAutoPlay",
+	CreativeAttributeInBannerVideoAdUserInitiated:    "InBannerVideoAdUserInitiated",
+	CreativeAttributePop:                             "Pop",
+	CreativeAttributeProvocativeOrSuggestiveImagery:  "ProvocativeOrSuggestiveImagery",
+	CreativeAttributeExtremeAnimation:                "ExtremeAnimation",
+	CreativeAttributeSurveys:                         "Surveys",
+	CreativeAttributeTextOnly:                        "TextOnly",
+	CreativeAttributeUserInitiated:                   "UserInitiated",
+	CreativeAttributeWindowsDialogOrAlert:            "WindowsDialogOrAlert",
+	CreativeAttributeHasAudioWithPlayer:              "HasAudioWithPlayer",
+	CreativeAttributeAdProvidesSkipButton:            "AdProvidesSkipButton",
+	CreativeAttributeAdobeFlash:                      "AdobeFlash",
+}
+
+// String returns the name of the CreativeAttribute.
+func (x CreativeAttribute) String() string {
+	return formatEnum(creativeAttributeNames, "CreativeAttribute", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x CreativeAttribute) IsValid() bool {
+	return x >= CreativeAttributeAudioAdAutoPlay && x <= CreativeAttributeAdobeFlash
+}
+
+// CreativeAttributeValues returns all valid CreativeAttribute values.
+func CreativeAttributeValues() []CreativeAttribute {
+	return []CreativeAttribute{
+		CreativeAttributeAudioAdAutoPlay,
+		CreativeAttributeAudioAdUserInitiated,
+		CreativeAttributeExpandableAuto,
+		CreativeAttributeExpandableUserInitiatedClick,
+		CreativeAttributeExpandableUserInitiatedRollover,
+		CreativeAttributeInBannerVideoAdAutoPlay,
+		CreativeAttributeInBannerVideoAdUserInitiated,
+		CreativeAttributePop,
+		CreativeAttributeProvocativeOrSuggestiveImagery,
+		CreativeAttributeExtremeAnimation,
+		CreativeAttributeSurveys,
+		CreativeAttributeTextOnly,
+		CreativeAttributeUserInitiated,
+		CreativeAttributeWindowsDialogOrAlert,
+		CreativeAttributeHasAudioWithPlayer,
+		CreativeAttributeAdProvidesSkipButton,
+		CreativeAttributeAdobeFlash,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseCreativeAttribute.
+func (x CreativeAttribute) Text() string {
+	return enumText(creativeAttributeNames, x, false)
+}
+
+// ParseCreativeAttribute returns the CreativeAttribute for a case-insensitive name or a numeric value.
+func ParseCreativeAttribute(s string) (CreativeAttribute, error) {
+	return parseEnum(creativeAttributeNames, "CreativeAttribute", s, false)
+}
+
+// Set parses s into x, see ParseCreativeAttribute. It implements flag.Value.
+func (x *CreativeAttribute) Set(s string) (err error) {
+	*x, err = ParseCreativeAttribute(s)
+	return err
+}
+
+var deviceTypeNames = map[DeviceType]string{
+	DeviceTypeUnknown:   "Unknown",
+	DeviceTypeMobile:    "Mobile",
+	DeviceTypePC:        "PC",
+	DeviceTypeTV:        "TV",
+	DeviceTypePhone:     "Phone",
+	DeviceTypeTablet:    "Tablet",
+	DeviceTypeConnected: "Connected",
+	DeviceTypeSetTopBox: "SetTopBox",
+	DeviceTypeOOH:       "OOH",
+}
+
+// String returns the name of the DeviceType.
+func (x DeviceType) String() string {
+	return formatEnum(deviceTypeNames, "DeviceType", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x DeviceType) IsValid() bool {
+	return x >= DeviceTypeMobile && x <= DeviceTypeOOH
+}
+
+// DeviceTypeValues returns all valid DeviceType values.
+func DeviceTypeValues() []DeviceType {
+	return []DeviceType{
+		DeviceTypeMobile,
+		DeviceTypePC,
+		DeviceTypeTV,
+		DeviceTypePhone,
+		DeviceTypeTablet,
+		DeviceTypeConnected,
+		DeviceTypeSetTopBox,
+		DeviceTypeOOH,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseDeviceType.
+func (x DeviceType) Text() string {
+	return enumText(deviceTypeNames, x, true)
+}
+
+// ParseDeviceType returns the DeviceType for a case-insensitive name or a numeric value.
+func ParseDeviceType(s string) (DeviceType, error) {
+	return parseEnum(deviceTypeNames, "DeviceType", s, true)
+}
+
+// Set parses s into x, see ParseDeviceType. It implements flag.Value.
+func (x *DeviceType) Set(s string) (err error) {
+	*x, err = ParseDeviceType(s)
+	return err
+}
+
+var expDirNames = map[ExpDir]string{
+	ExpDirUnknown:    "Unknown",
+	ExpDirLeft:       "Left",
+	ExpDirRight:      "Right",
+	ExpDirUp:         "Up",
+	ExpDirDown:       "Down",
+	ExpDirFullScreen: "FullScreen",
+}
+
+// String returns the name of the ExpDir.
+func (x ExpDir) String() string {
+	return formatEnum(expDirNames, "ExpDir", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x ExpDir) IsValid() bool {
+	return x >= ExpDirLeft && x <= ExpDirFullScreen
+}
+
+// ExpDirValues returns all valid ExpDir values.
+func ExpDirValues() []ExpDir {
+	return []ExpDir{
+		ExpDirLeft,
+		ExpDirRight,
+		ExpDirUp,
+		ExpDirDown,
+		ExpDirFullScreen,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseExpDir.
+func (x ExpDir) Text() string {
+	return enumText(expDirNames, x, true)
+}
+
+// ParseExpDir returns the ExpDir for a case-insensitive name or a numeric value.
+func ParseExpDir(s string) (ExpDir, error) {
+	return parseEnum(expDirNames, "ExpDir", s, true)
+}
+
+// Set parses s into x, see ParseExpDir. It implements flag.Value.
+func (x *ExpDir) Set(s string) (err error) {
+	*x, err = ParseExpDir(s)
+	return err
+}
+
+var feedTypeNames = map[FeedType]string{
+	FeedTypeUnknown:   "Unknown",
+	FeedTypeMusic:     "Music",
+	FeedTypeBroadcast: "Broadcast",
+	FeedTypePodcast:   "Podcast",
+}
+
+// String returns the name of the FeedType.
+func (x FeedType) String() string {
+	return formatEnum(feedTypeNames, "FeedType", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x FeedType) IsValid() bool {
+	return x >= FeedTypeMusic && x <= FeedTypePodcast
+}
+
+// FeedTypeValues returns all valid FeedType values.
+func FeedTypeValues() []FeedType {
+	return []FeedType{
+		FeedTypeMusic,
+		FeedTypeBroadcast,
+		FeedTypePodcast,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseFeedType.
+func (x FeedType) Text() string {
+	return enumText(feedTypeNames, x, true)
+}
+
+// ParseFeedType returns the FeedType for a case-insensitive name or a numeric value.
+func ParseFeedType(s string) (FeedType, error) {
+	return parseEnum(feedTypeNames, "FeedType", s, true)
+}
+
+// Set parses s into x, see ParseFeedType. It implements flag.Value.
+func (x *FeedType) Set(s string) (err error) {
+	*x, err = ParseFeedType(s)
+	return err
+}
+
+var ipLocationNames = map[IPLocation]string{
+	IPLocationUnknown:     "Unknown",
+	IPLocationIP2Location: "IP2Location",
+	IPLocationNeustar:     "Neustar",
+	IPLocationMaxMind:     "MaxMind",
+	IPLocationNetAquity:   "NetAquity",
+}
+
+// String returns the name of the IPLocation.
+func (x IPLocation) String() string {
+	return formatEnum(ipLocationNames, "IPLocation", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x IPLocation) IsValid() bool {
+	return x >= IPLocationIP2Location && x <= IPLocationNetAquity
+}
+
+// IPLocationValues returns all valid IPLocation values.
+func IPLocationValues() []IPLocation {
+	return []IPLocation{
+		IPLocationIP2Location,
+		IPLocationNeustar,
+		IPLocationMaxMind,
+		IPLocationNetAquity,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseIPLocation.
+func (x IPLocation) Text() string {
+	return enumText(ipLocationNames, x, true)
+}
+
+// ParseIPLocation returns the IPLocation for a case-insensitive name or a numeric value.
+func ParseIPLocation(s string) (IPLocation, error) {
+	return parseEnum(ipLocationNames, "IPLocation", s, true)
+}
+
+// Set parses s into x, see ParseIPLocation. It implements flag.Value.
+func (x *IPLocation) Set(s string) (err error) {
+	*x, err = ParseIPLocation(s)
+	return err
+}
+
+var iqgRatingNames = map[IQGRating]string{
+	IQGRatingUnknown: "Unknown",
+	IQGRatingAll:     "All",
+	IQGRatingOver12:  "Over12",
+	IQGRatingMature:  "Mature",
+}
+
+// String returns the name of the IQGRating.
+func (x IQGRating) String() string {
+	return formatEnum(iqgRatingNames, "IQGRating", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x IQGRating) IsValid() bool {
+	return x >= IQGRatingAll && x <= IQGRatingMature
+}
+
+// IQGRatingValues returns all valid IQGRating values.
+func IQGRatingValues() []IQGRating {
+	return []IQGRating{
+		IQGRatingAll,
+		IQGRatingOver12,
+		IQGRatingMature,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseIQGRating.
+func (x IQGRating) Text() string {
+	return enumText(iqgRatingNames, x, true)
+}
+
+// ParseIQGRating returns the IQGRating for a case-insensitive name or a numeric value.
+func ParseIQGRating(s string) (IQGRating, error) {
+	return parseEnum(iqgRatingNames, "IQGRating", s, true)
+}
+
+// Set parses s into x, see ParseIQGRating. It implements flag.Value.
+func (x *IQGRating) Set(s string) (err error) {
+	*x, err = ParseIQGRating(s)
+	return err
+}
+
+var locationTypeNames = map[LocationType]string{
+	LocationTypeUnknown: "Unknown",
+	LocationTypeGPS:     "GPS",
+	LocationTypeIP:      "IP",
+	LocationTypeUser:    "User",
+}
+
+// String returns the name of the LocationType.
+func (x LocationType) String() string {
+	return formatEnum(locationTypeNames, "LocationType", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x LocationType) IsValid() bool {
+	return x >= LocationTypeGPS && x <= LocationTypeUser
+}
+
+// LocationTypeValues returns all valid LocationType values.
+func LocationTypeValues() []LocationType {
+	return []LocationType{
+		LocationTypeGPS,
+		LocationTypeIP,
+		LocationTypeUser,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseLocationType.
+func (x LocationType) Text() string {
+	return enumText(locationTypeNames, x, true)
+}
+
+// ParseLocationType returns the LocationType for a case-insensitive name or a numeric value.
+func ParseLocationType(s string) (LocationType, error) {
+	return parseEnum(locationTypeNames, "LocationType", s, true)
+}
+
+// Set parses s into x, see ParseLocationType. It implements flag.Value.
+func (x *LocationType) Set(s string) (err error) {
+	*x, err = ParseLocationType(s)
+	return err
+}
+
+var markupTypeNames = map[MarkupType]string{
+	MarkupUnknown: "Unknown",
+	MarkupBanner:  "Banner",
+	MarkupVideo:   "Video",
+	MarkupAudio:   "Audio",
+	MarkupNative:  "Native",
+}
+
+// String returns the name of the MarkupType.
+func (x MarkupType) String() string {
+	return formatEnum(markupTypeNames, "MarkupType", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x MarkupType) IsValid() bool {
+	return x >= MarkupBanner && x <= MarkupNative
+}
+
+// MarkupTypeValues returns all valid MarkupType values.
+func MarkupTypeValues() []MarkupType {
+	return []MarkupType{
+		MarkupBanner,
+		MarkupVideo,
+		MarkupAudio,
+		MarkupNative,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseMarkupType.
+func (x MarkupType) Text() string {
+	return enumText(markupTypeNames, x, true)
+}
+
+// ParseMarkupType returns the MarkupType for a case-insensitive name or a numeric value.
+func ParseMarkupType(s string) (MarkupType, error) {
+	return parseEnum(markupTypeNames, "MarkupType", s, true)
+}
+
+// Set parses s into x, see ParseMarkupType. It implements flag.Value.
+func (x *MarkupType) Set(s string) (err error) {
+	*x, err = ParseMarkupType(s)
+	return err
+}
+
+var nbrNames = map[NBR]string{
+	NBRUnknownError:      "UnknownError",
+	NBRTechnicalError:    "TechnicalError",
+	NBRInvalidRequest:    "InvalidRequest",
+	NBRKnownSpider:       "KnownSpider",
+	NBRSuspectedNonHuman: "SuspectedNonHuman",
+	NBRProxyIP:           "ProxyIP",
+	NBRUnsupportedDevice: "UnsupportedDevice",
+	NBRBlockedSite:       "BlockedSite",
+	NBRUnmatchedUser:     "UnmatchedUser",
+}
+
+// String returns the name of the NBR.
+func (x NBR) String() string {
+	return formatEnum(nbrNames, "NBR", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x NBR) IsValid() bool {
+	return x >= NBRUnknownError && x <= NBRUnmatchedUser
+}
+
+// NBRValues returns all valid NBR values.
+func NBRValues() []NBR {
+	return []NBR{
+		NBRUnknownError,
+		NBRTechnicalError,
+		NBRInvalidRequest,
+		NBRKnownSpider,
+		NBRSuspectedNonHuman,
+		NBRProxyIP,
+		NBRUnsupportedDevice,
+		NBRBlockedSite,
+		NBRUnmatchedUser,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseNBR.
+func (x NBR) Text() string {
+	return enumText(nbrNames, x, false)
+}
+
+// ParseNBR returns the NBR for a case-insensitive name or a numeric value.
+func ParseNBR(s string) (NBR, error) {
+	return parseEnum(nbrNames, "NBR", s, false)
+}
+
+// Set parses s into x, see ParseNBR. It implements flag.Value.
+func (x *NBR) Set(s string) (err error) {
+	*x, err = ParseNBR(s)
+	return err
+}
+
+var podSequenceNames = map[PodSequence]string{
+	PodSeqLast:  "Last",
+	PodSeqAny:   "Any",
+	PodSeqFirst: "First",
+}
+
+// String returns the name of the PodSequence.
+func (x PodSequence) String() string {
+	return formatEnum(podSequenceNames, "PodSequence", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x PodSequence) IsValid() bool {
+	return x >= PodSeqLast && x <= PodSeqFirst
+}
+
+// PodSequenceValues returns all valid PodSequence values.
+func PodSequenceValues() []PodSequence {
+	return []PodSequence{
+		PodSeqLast,
+		PodSeqAny,
+		PodSeqFirst,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParsePodSequence.
+func (x PodSequence) Text() string {
+	return enumText(podSequenceNames, x, false)
+}
+
+// ParsePodSequence returns the PodSequence for a case-insensitive name or a numeric value.
+func ParsePodSequence(s string) (PodSequence, error) {
+	return parseEnum(podSequenceNames, "PodSequence", s, false)
+}
+
+// Set parses s into x, see ParsePodSequence. It implements flag.Value.
+func (x *PodSequence) Set(s string) (err error) {
+	*x, err = ParsePodSequence(s)
+	return err
+}
+
+var productionQualityNames = map[ProductionQuality]string{
+	ProductionQualityUnknown:      "Unknown",
+	ProductionQualityProfessional: "Professional",
+	ProductionQualityProsumer:     "Prosumer",
+	ProductionQualityUGC:          "UGC",
+}
+
+// String returns the name of the ProductionQuality.
+func (x ProductionQuality) String() string {
+	return formatEnum(productionQualityNames, "ProductionQuality", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x ProductionQuality) IsValid() bool {
+	return x >= ProductionQualityUnknown && x <= ProductionQualityUGC
+}
+
+// ProductionQualityValues returns all valid ProductionQuality values.
+func ProductionQualityValues() []ProductionQuality {
+	return []ProductionQuality{
+		ProductionQualityUnknown,
+		ProductionQualityProfessional,
+		ProductionQualityProsumer,
+		ProductionQualityUGC,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseProductionQuality.
+func (x ProductionQuality) Text() string {
+	return enumText(productionQualityNames, x, false)
+}
+
+// ParseProductionQuality returns the ProductionQuality for a case-insensitive name or a numeric value.
+func ParseProductionQuality(s string) (ProductionQuality, error) {
+	return parseEnum(productionQualityNames, "ProductionQuality", s, false)
+}
+
+// Set parses s into x, see ParseProductionQuality. It implements flag.Value.
+func (x *ProductionQuality) Set(s string) (err error) {
+	*x, err = ParseProductionQuality(s)
+	return err
+}
+
+var protocolNames = map[Protocol]string{
+	ProtocolUnknown:       "Unknown",
+	ProtocolVAST1:         "VAST1",
+	ProtocolVAST2:         "VAST2",
+	ProtocolVAST3:         "VAST3",
+	ProtocolVAST1Wrapper:  "VAST1Wrapper",
+	ProtocolVAST2Wrapper:  "VAST2Wrapper",
+	ProtocolVAST3Wrapper:  "VAST3Wrapper",
+	ProtocolVAST4:         "VAST4",
+	ProtocolVAST4Wrapper:  "VAST4Wrapper",
+	ProtocolDAAST1:        "DAAST1",
+	ProtocolDAAST1Wrapper: "DAAST1Wrapper",
+}
+
+// String returns the name of the Protocol.
+func (x Protocol) String() string {
+	return formatEnum(protocolNames, "Protocol", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x Protocol) IsValid() bool {
+	return x >= ProtocolVAST1 && x <= ProtocolDAAST1Wrapper
+}
+
+// ProtocolValues returns all valid Protocol values.
+func ProtocolValues() []Protocol {
+	return []Protocol{
+		ProtocolVAST1,
+		ProtocolVAST2,
+		ProtocolVAST3,
+		ProtocolVAST1Wrapper,
+		ProtocolVAST2Wrapper,
+		ProtocolVAST3Wrapper,
+		ProtocolVAST4,
+		ProtocolVAST4Wrapper,
+		ProtocolDAAST1,
+		ProtocolDAAST1Wrapper,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseProtocol.
+func (x Protocol) Text() string {
+	return enumText(protocolNames, x, true)
+}
+
+// ParseProtocol returns the Protocol for a case-insensitive name or a numeric value.
+func ParseProtocol(s string) (Protocol, error) {
+	return parseEnum(protocolNames, "Protocol", s, true)
+}
+
+// Set parses s into x, see ParseProtocol. It implements flag.Value.
+func (x *Protocol) Set(s string) (err error) {
+	*x, err = ParseProtocol(s)
+	return err
+}
+
+var slotPositionInPodNames = map[SlotPositionInPod]string{
+	SlotPosLast:        "Last",
+	SlotPosAny:         "Any",
+	SlotPosFirst:       "First",
+	SlotPosFirstOrLast: "FirstOrLast",
+}
+
+// String returns the name of the SlotPositionInPod.
+func (x SlotPositionInPod) String() string {
+	return formatEnum(slotPositionInPodNames, "SlotPositionInPod", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x SlotPositionInPod) IsValid() bool {
+	return x >= SlotPosLast && x <= SlotPosFirstOrLast
+}
+
+// SlotPositionInPodValues returns all valid SlotPositionInPod values.
+func SlotPositionInPodValues() []SlotPositionInPod {
+	return []SlotPositionInPod{
+		SlotPosLast,
+		SlotPosAny,
+		SlotPosFirst,
+		SlotPosFirstOrLast,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseSlotPositionInPod.
+func (x SlotPositionInPod) Text() string {
+	return enumText(slotPositionInPodNames, x, false)
+}
+
+// ParseSlotPositionInPod returns the SlotPositionInPod for a case-insensitive name or a numeric value.
+func ParseSlotPositionInPod(s string) (SlotPositionInPod, error) {
+	return parseEnum(slotPositionInPodNames, "SlotPositionInPod", s, false)
+}
+
+// Set parses s into x, see ParseSlotPositionInPod. It implements flag.Value.
+func (x *SlotPositionInPod) Set(s string) (err error) {
+	*x, err = ParseSlotPositionInPod(s)
+	return err
+}
+
+var uaSourceNames = map[UASource]string{
+	UASourceUnknown:     "Unknown",
+	UASourceLowEntropy:  "LowEntropy",
+	UASourceHighEntropy: "HighEntropy",
+	UASourceUserAgent:   "UserAgent",
+}
+
+// String returns the name of the UASource.
+func (x UASource) String() string {
+	return formatEnum(uaSourceNames, "UASource", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x UASource) IsValid() bool {
+	return x >= UASourceUnknown && x <= UASourceUserAgent
+}
+
+// UASourceValues returns all valid UASource values.
+func UASourceValues() []UASource {
+	return []UASource{
+		UASourceUnknown,
+		UASourceLowEntropy,
+		UASourceHighEntropy,
+		UASourceUserAgent,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseUASource.
+func (x UASource) Text() string {
+	return enumText(uaSourceNames, x, false)
+}
+
+// ParseUASource returns the UASource for a case-insensitive name or a numeric value.
+func ParseUASource(s string) (UASource, error) {
+	return parseEnum(uaSourceNames, "UASource", s, false)
+}
+
+// Set parses s into x, see ParseUASource. It implements flag.Value.
+func (x *UASource) Set(s string) (err error) {
+	*x, err = ParseUASource(s)
+	return err
+}
+
+var videoLinearityNames = map[VideoLinearity]string{
+	VideoLinearityUnknown:   "Unknown",
+	VideoLinearityLinear:    "Linear",
+	VideoLinearityNonLinear: "NonLinear",
+}
+
+// String returns the name of the VideoLinearity.
+func (x VideoLinearity) String() string {
+	return formatEnum(videoLinearityNames, "VideoLinearity", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x VideoLinearity) IsValid() bool {
+	return x >= VideoLinearityLinear && x <= VideoLinearityNonLinear
+}
+
+// VideoLinearityValues returns all valid VideoLinearity values.
+func VideoLinearityValues() []VideoLinearity {
+	return []VideoLinearity{
+		VideoLinearityLinear,
+		VideoLinearityNonLinear,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseVideoLinearity.
+func (x VideoLinearity) Text() string {
+	return enumText(videoLinearityNames, x, true)
+}
+
+// ParseVideoLinearity returns the VideoLinearity for a case-insensitive name or a numeric value.
+func ParseVideoLinearity(s string) (VideoLinearity, error) {
+	return parseEnum(videoLinearityNames, "VideoLinearity", s, true)
+}
+
+// Set parses s into x, see ParseVideoLinearity. It implements flag.Value.
+func (x *VideoLinearity) Set(s string) (err error) {
+	*x, err = ParseVideoLinearity(s)
+	return err
+}
+
+var videoPlacementNames = map[VideoPlacement]string{
+	VideoPlacementUnknown:      "Unknown",
+	VideoPlacementInStream:     "InStream",
+	VideoPlacementInBanner:     "InBanner",
+	VideoPlacementInArticle:    "InArticle",
+	VideoPlacementInFeed:       "InFeed",
+	VideoPlacementInterstitial: "Interstitial",
+}
+
+// String returns the name of the VideoPlacement.
+func (x VideoPlacement) String() string {
+	return formatEnum(videoPlacementNames, "VideoPlacement", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x VideoPlacement) IsValid() bool {
+	return x >= VideoPlacementInStream && x <= VideoPlacementInterstitial
+}
+
+// VideoPlacementValues returns all valid VideoPlacement values.
+func VideoPlacementValues() []VideoPlacement {
+	return []VideoPlacement{
+		VideoPlacementInStream,
+		VideoPlacementInBanner,
+		VideoPlacementInArticle,
+		VideoPlacementInFeed,
+		VideoPlacementInterstitial,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseVideoPlacement.
+func (x VideoPlacement) Text() string {
+	return enumText(videoPlacementNames, x, true)
+}
+
+// ParseVideoPlacement returns the VideoPlacement for a case-insensitive name or a numeric value.
+func ParseVideoPlacement(s string) (VideoPlacement, error) {
+	return parseEnum(videoPlacementNames, "VideoPlacement", s, true)
+}
+
+// Set parses s into x, see ParseVideoPlacement. It implements flag.Value.
+func (x *VideoPlacement) Set(s string) (err error) {
+	*x, err = ParseVideoPlacement(s)
+	return err
+}
+
+var videoPlaybackNames = map[VideoPlayback]string{
+	VideoPlaybackUnknown:          "Unknown",
+	VideoPlaybackPageLoadSoundOn:  "PageLoadSoundOn",
+	VideoPlaybackPageLoadSoundOff: "PageLoadSoundOff",
+	VideoPlaybackClickToPlay:      "ClickToPlay",
+	VideoPlaybackMouseOver:        "MouseOver",
+	VideoPlaybackEnterSoundOn:     "EnterSoundOn",
+	VideoPlaybackEnterSoundOff:    "EnterSoundOff",
+}
+
+// String returns the name of the VideoPlayback.
+func (x VideoPlayback) String() string {
+	return formatEnum(videoPlaybackNames, "VideoPlayback", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x VideoPlayback) IsValid() bool {
+	return x >= VideoPlaybackPageLoadSoundOn && x <= VideoPlaybackEnterSoundOff
+}
+
+// VideoPlaybackValues returns all valid VideoPlayback values.
+func VideoPlaybackValues() []VideoPlayback {
+	return []VideoPlayback{
+		VideoPlaybackPageLoadSoundOn,
+		VideoPlaybackPageLoadSoundOff,
+		VideoPlaybackClickToPlay,
+		VideoPlaybackMouseOver,
+		VideoPlaybackEnterSoundOn,
+		VideoPlaybackEnterSoundOff,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseVideoPlayback.
+func (x VideoPlayback) Text() string {
+	return enumText(videoPlaybackNames, x, true)
+}
+
+// ParseVideoPlayback returns the VideoPlayback for a case-insensitive name or a numeric value.
+func ParseVideoPlayback(s string) (VideoPlayback, error) {
+	return parseEnum(videoPlaybackNames, "VideoPlayback", s, true)
+}
+
+// Set parses s into x, see ParseVideoPlayback. It implements flag.Value.
+func (x *VideoPlayback) Set(s string) (err error) {
+	*x, err = ParseVideoPlayback(s)
+	return err
+}
+
+var videoPlcmtNames = map[VideoPlcmt]string{
+	VideoPlcmtInstream:            "Instream",
+	VideoPlcmtAccompanyingContent: "AccompanyingContent",
+	VideoPlcmtInterstitial:        "Interstitial",
+	VideoPlcmtNoContent:           "NoContent",
+}
+
+// String returns the name of the VideoPlcmt.
+func (x VideoPlcmt) String() string {
+	return formatEnum(videoPlcmtNames, "VideoPlcmt", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x VideoPlcmt) IsValid() bool {
+	return x >= VideoPlcmtInstream && x <= VideoPlcmtNoContent
+}
+
+// VideoPlcmtValues returns all valid VideoPlcmt values.
+func VideoPlcmtValues() []VideoPlcmt {
+	return []VideoPlcmt{
+		VideoPlcmtInstream,
+		VideoPlcmtAccompanyingContent,
+		VideoPlcmtInterstitial,
+		VideoPlcmtNoContent,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseVideoPlcmt.
+func (x VideoPlcmt) Text() string {
+	return enumText(videoPlcmtNames, x, false)
+}
+
+// ParseVideoPlcmt returns the VideoPlcmt for a case-insensitive name or a numeric value.
+func ParseVideoPlcmt(s string) (VideoPlcmt, error) {
+	return parseEnum(videoPlcmtNames, "VideoPlcmt", s, false)
+}
+
+// Set parses s into x, see ParseVideoPlcmt. It implements flag.Value.
+func (x *VideoPlcmt) Set(s string) (err error) {
+	*x, err = ParseVideoPlcmt(s)
+	return err
+}
+
+var volumeNormNames = map[VolumeNorm]string{
+	VolumeNormNone:     "None",
+	VolumeNormAverage:  "Average",
+	VolumeNormPeak:     "Peak",
+	VolumeNormLoudness: "Loudness",
+	VolumeNormCustom:   "Custom",
+}
+
+// String returns the name of the VolumeNorm.
+func (x VolumeNorm) String() string {
+	return formatEnum(volumeNormNames, "VolumeNorm", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x VolumeNorm) IsValid() bool {
+	return x >= VolumeNormNone && x <= VolumeNormCustom
+}
+
+// VolumeNormValues returns all valid VolumeNorm values.
+func VolumeNormValues() []VolumeNorm {
+	return []VolumeNorm{
+		VolumeNormNone,
+		VolumeNormAverage,
+		VolumeNormPeak,
+		VolumeNormLoudness,
+		VolumeNormCustom,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseVolumeNorm.
+func (x VolumeNorm) Text() string {
+	return enumText(volumeNormNames, x, false)
+}
+
+// ParseVolumeNorm returns the VolumeNorm for a case-insensitive name or a numeric value.
+func ParseVolumeNorm(s string) (VolumeNorm, error) {
+	return parseEnum(volumeNormNames, "VolumeNorm", s, false)
+}
+
+// Set parses s into x, see ParseVolumeNorm. It implements flag.Value.
+func (x *VolumeNorm) Set(s string) (err error) {
+	*x, err = ParseVolumeNorm(s)
+	return err
+}
+
+type enumInt interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+func formatEnum[T enumInt](names map[T]string, typ string, x T) string {
+	if s, ok := names[x]; ok {
+		return s
+	}
+	return typ + "(" + strconv.FormatInt(int64(x), 10) + ")"
+}
+
+// enumText returns the name of x, or its number if it has none. If zeroUnset
+// is set, the zero value only marks an unset field and has no name.
+func enumText[T enumInt](names map[T]string, x T, zeroUnset bool) string {
+	if s, ok := names[x]; ok && (x != 0 || !zeroUnset) {
+		return s
+	}
+	return strconv.FormatInt(int64(x), 10)
+}
+
+func parseEnum[T enumInt](names map[T]string, typ, s string, zeroUnset bool) (T, error) {
+	for x, name := range names {
+		if strings.EqualFold(name, s) && (x != 0 || !zeroUnset) {
+			return x, nil
+		}
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && int64(T(n)) == n {
+		return T(n), nil
+	}
+	return 0, fmt.Errorf("%w: %s %q", ErrEnumName, typ, s)
+}
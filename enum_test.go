@@ -0,0 +1,227 @@
+package openrtb_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestEnum_String(t *testing.T) {
+	for exp, x := range map[string]interface{ String() string }{
+		"VAST4":           ProtocolVAST4,
+		"Unknown":         ProtocolUnknown,
+		"Protocol(11)":    Protocol(11),
+		"MRAID3":          APIFrameworkMRAID3,
+		"Phone":           DeviceTypePhone,
+		"Cell4G":          ConnTypeCell4G,
+		"ProxyIP":         NBRProxyIP,
+		"Last":            PodSeqLast,
+		"FirstOrLast":     SlotPosFirstOrLast,
+		"Video":           MarkupVideo,
+		"NoContent":       VideoPlcmtNoContent,
+		"AudioAdAutoPlay": CreativeAttributeAudioAdAutoPlay,
+	} {
+		if got := x.String(); exp != got {
+			t.Errorf("expected %v, got %v", exp, got)
+		}
+	}
+}
+
+func TestEnum_Parse(t *testing.T) {
+	for s, exp := range map[string]Protocol{
+		"VAST4":        ProtocolVAST4,
+		"vast4":        ProtocolVAST4,
+		"Vast3Wrapper": ProtocolVAST3Wrapper,
+		"7":            ProtocolVAST4,
+		"11":           Protocol(11),
+	} {
+		got, err := ParseProtocol(s)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if exp != got {
+			t.Errorf("%q: expected %v, got %v", s, exp, got)
+		}
+	}
+
+	for _, s := range []string{"", "vast5", "1.0", "300"} {
+		if _, err := ParseProtocol(s); !errors.Is(err, ErrEnumName) {
+			t.Errorf("%q: expected %v, got %v", s, ErrEnumName, err)
+		}
+	}
+
+	if exp, got := PodSeqLast, mustParse(t, ParsePodSequence, "-1"); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestEnum_IsValid(t *testing.T) {
+	for x, exp := range map[interface{ IsValid() bool }]bool{
+		ProtocolUnknown:        false,
+		ProtocolVAST1:          true,
+		ProtocolDAAST1Wrapper:  true,
+		Protocol(11):           false,
+		AdPositionUnknown:      true,
+		AdPositionMaybeVisible: true,
+		AdPosition(8):          false,
+		ConnTypeUnknown:        true,
+		DeviceTypeUnknown:      false,
+		DeviceTypeOOH:          true,
+		PodSeqLast:             true,
+		PodSequence(-2):        false,
+		ContentContextUnknown:  true,
+	} {
+		if got := x.IsValid(); exp != got {
+			t.Errorf("%v: expected %v, got %v", x, exp, got)
+		}
+	}
+}
+
+func TestEnum_Values(t *testing.T) {
+	if exp, got := []VideoPlcmt{
+		VideoPlcmtInstream,
+		VideoPlcmtAccompanyingContent,
+		VideoPlcmtInterstitial,
+		VideoPlcmtNoContent,
+	}, VideoPlcmtValues(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	if exp, got := 10, len(ProtocolValues()); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	for _, x := range CreativeAttributeValues() {
+		if !x.IsValid() {
+			t.Errorf("expected %v to be valid", x)
+		}
+	}
+}
+
+func TestEnum_Text(t *testing.T) {
+	for x, exp := range map[Protocol]string{
+		ProtocolVAST4:   "VAST4",
+		ProtocolUnknown: "0",
+		Protocol(11):    "11",
+	} {
+		if got := x.Text(); exp != got {
+			t.Errorf("expected %v, got %v", exp, got)
+		}
+
+		var got Protocol
+		if err := got.Set(x.Text()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if x != got {
+			t.Errorf("expected %v, got %v", x, got)
+		}
+	}
+
+	if exp, got := "Unknown", AdPositionUnknown.Text(); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	var x DeviceType
+	for _, s := range []string{"television", "unknown"} {
+		if err := x.Set(s); !errors.Is(err, ErrEnumName) {
+			t.Errorf("%q: expected %v, got %v", s, ErrEnumName, err)
+		}
+	}
+}
+
+func TestNamed(t *testing.T) {
+	subject := struct {
+		Protocols []Named[Protocol]  `json:"protocols"`
+		Type      Named[DeviceType]  `json:"type"`
+		Keys      map[Named[NBR]]int `json:"keys"`
+	}{
+		Protocols: []Named[Protocol]{{Value: ProtocolVAST4}, {Value: Protocol(11)}},
+		Type:      Named[DeviceType]{Value: DeviceTypePhone},
+		Keys:      map[Named[NBR]]int{{Value: NBRProxyIP}: 1},
+	}
+
+	data, err := json.Marshal(subject)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := `{"protocols":["VAST4","11"],"type":"Phone","keys":{"ProxyIP":1}}`, string(data); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	got := subject
+	got.Protocols, got.Type, got.Keys = nil, Named[DeviceType]{}, nil
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(subject, got) {
+		t.Errorf("expected %+v, got %+v", subject, got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"television"}`), &got); !errors.Is(err, ErrEnumName) {
+		t.Errorf("expected %v, got %v", ErrEnumName, err)
+	}
+	if exp, got := "VAST4", subject.Protocols[0].String(); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestEnum_json(t *testing.T) {
+	subject := &Geo{Country: "GBR", Type: LocationTypeIP, IPService: IPLocationMaxMind}
+	data, err := json.Marshal(subject)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := `{"country":"GBR","type":2,"ipservice":3}`, string(data); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	var got *Geo
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(subject, got) {
+		t.Errorf("expected %+v, got %+v", subject, got)
+	}
+
+	data, err = json.Marshal([]Protocol{ProtocolVAST3, ProtocolVAST4})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := `[3,7]`, string(data); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	data, err = json.Marshal(map[Protocol]int{ProtocolVAST4: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := `{"7":2}`, string(data); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	var x Protocol
+	for _, data := range []string{`"VAST4"`, `"7"`, `7.5`, `300`, `true`} {
+		if err := json.Unmarshal([]byte(data), &x); err == nil {
+			t.Errorf("expected error for %s", data)
+		}
+	}
+	if err := json.Unmarshal([]byte(`-1`), &x); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := Protocol(-1), x; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func mustParse[T any](t *testing.T, parse func(string) (T, error), s string) T {
+	t.Helper()
+
+	x, err := parse(s)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return x
+}
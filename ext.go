@@ -0,0 +1,335 @@
+package openrtb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/goccy/go-json"
+)
+
+// Extension errors
+var (
+	ErrExtNotFound     = errors.New("openrtb: ext key not found")
+	ErrExtTypeMismatch = errors.New("openrtb: ext type does not match registration")
+	ErrExtInvalid      = errors.New("openrtb: ext value is invalid")
+)
+
+// ExtKind identifies the kind of object an ext belongs to.
+type ExtKind string
+
+// ExtKind options, named after the JSON attributes of the objects.
+const (
+	ExtBidRequest      ExtKind = "request"
+	ExtImpression      ExtKind = "imp"
+	ExtBanner          ExtKind = "banner"
+	ExtFormat          ExtKind = "format"
+	ExtVideo           ExtKind = "video"
+	ExtAudio           ExtKind = "audio"
+	ExtNative          ExtKind = "native"
+	ExtPMP             ExtKind = "pmp"
+	ExtDeal            ExtKind = "deal"
+	ExtSite            ExtKind = "site"
+	ExtApp             ExtKind = "app"
+	ExtPublisher       ExtKind = "publisher"
+	ExtContent         ExtKind = "content"
+	ExtProducer        ExtKind = "producer"
+	ExtChannel         ExtKind = "channel"
+	ExtDevice          ExtKind = "device"
+	ExtUserAgent       ExtKind = "sua"
+	ExtGeo             ExtKind = "geo"
+	ExtUser            ExtKind = "user"
+	ExtData            ExtKind = "data"
+	ExtSegment         ExtKind = "segment"
+	ExtRegulations     ExtKind = "regs"
+	ExtSource          ExtKind = "source"
+	ExtSupplyChain     ExtKind = "schain"
+	ExtSupplyChainNode ExtKind = "node"
+	ExtEID             ExtKind = "eid"
+	ExtUID             ExtKind = "uid"
+	ExtBidResponse     ExtKind = "response"
+	ExtSeatBid         ExtKind = "seatbid"
+	ExtBid             ExtKind = "bid"
+	ExtBrandVersion    ExtKind = "brandversion"
+)
+
+type extRegKey struct {
+	kind ExtKind
+	key  string
+}
+
+var extRegistry = struct {
+	sync.RWMutex
+	types map[extRegKey]reflect.Type
+}{types: make(map[extRegKey]reflect.Type)}
+
+// RegisterExt registers type T for the given ext key of an object kind, e.g.
+//
+//	openrtb.RegisterExt[PrebidExt](openrtb.ExtBidRequest, "prebid")
+//	openrtb.RegisterExt[DSAExt](openrtb.ExtRegulations, "dsa")
+//
+// It panics if a different type is already registered for the same key.
+func RegisterExt[T any](kind ExtKind, key string) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	rk := extRegKey{kind: kind, key: key}
+
+	extRegistry.Lock()
+	defer extRegistry.Unlock()
+
+	if prev, ok := extRegistry.types[rk]; ok && prev != t {
+		panic(fmt.Sprintf("openrtb: ext %s.%s already registered as %s", kind, key, prev))
+	}
+	extRegistry.types[rk] = t
+}
+
+// RegisteredExt returns the type registered for an ext key of an object kind.
+func RegisteredExt(kind ExtKind, key string) (reflect.Type, bool) {
+	extRegistry.RLock()
+	t, ok := extRegistry.types[extRegKey{kind: kind, key: key}]
+	extRegistry.RUnlock()
+	return t, ok
+}
+
+// checkExt returns ErrExtTypeMismatch if a type other than t is registered
+// for the ext key of an object kind. Pointers to the registered type match.
+func checkExt(kind ExtKind, key string, t reflect.Type) error {
+	if rt, ok := RegisteredExt(kind, key); ok && rt != t && reflect.PtrTo(rt) != t {
+		return fmt.Errorf("%w: %s.%s is %s, not %s", ErrExtTypeMismatch, kind, key, rt, t)
+	}
+	return nil
+}
+
+// GetExt decodes the value stored under key in the raw ext of an object kind,
+// e.g.
+//
+//	prebid, err := openrtb.GetExt[PrebidExt](openrtb.ExtBidRequest, req.Ext, "prebid")
+//
+// If a type is registered for the key, T must match it either directly or as
+// a pointer. It returns ErrExtNotFound if the key is not present.
+func GetExt[T any](kind ExtKind, ext json.RawMessage, key string) (T, error) {
+	var v T
+
+	if err := checkExt(kind, key, reflect.TypeOf((*T)(nil)).Elem()); err != nil {
+		return v, err
+	}
+
+	raw, err := extMember(ext, key)
+	if err != nil {
+		return v, err
+	}
+	err = json.Unmarshal(raw, &v)
+	return v, err
+}
+
+// SetExt encodes v and stores it under key in a raw ext object, all other
+// members of the ext are preserved. A nil v removes the key.
+func SetExt(ext json.RawMessage, key string, v interface{}) (json.RawMessage, error) {
+	members, err := extMembers(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	if v == nil {
+		delete(members, key)
+	} else {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		members[key] = raw
+	}
+
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(members)
+}
+
+func extMembers(ext json.RawMessage) (map[string]json.RawMessage, error) {
+	members := make(map[string]json.RawMessage)
+	if len(ext) == 0 || string(ext) == "null" {
+		return members, nil
+	}
+	if err := json.Unmarshal(ext, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func extMember(ext json.RawMessage, key string) (json.RawMessage, error) {
+	members, err := extMembers(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := members[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrExtNotFound, key)
+	}
+	return raw, nil
+}
+
+// --------------------------------------------------------------------
+
+// ExtCache caches the decoded values of a single ext object, so that each
+// member is only parsed once, even when accessed by multiple consumers.
+// Modified values are merged back into the raw ext on Marshal, which also
+// releases all cached values. ExtCache is safe for concurrent use.
+type ExtCache struct {
+	kind ExtKind
+
+	mu      sync.Mutex
+	raw     json.RawMessage
+	members map[string]json.RawMessage
+	values  map[string]interface{}
+	dirty   map[string]struct{}
+}
+
+// NewExtCache creates a cache for the raw ext of an object kind.
+func NewExtCache(kind ExtKind, ext json.RawMessage) *ExtCache {
+	return &ExtCache{kind: kind, raw: ext}
+}
+
+// Get returns the value stored under key, decoded into the registered type.
+// The value is a pointer to the registered type. Keys without registration
+// are decoded into generic JSON values.
+func (c *ExtCache) Get(key string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := c.values[key]; ok {
+		if v == nil {
+			return nil, fmt.Errorf("%w: %s", ErrExtNotFound, key)
+		}
+		return v, nil
+	}
+
+	var v interface{} = new(interface{})
+	if t, ok := RegisteredExt(c.kind, key); ok {
+		v = reflect.New(t).Interface()
+	}
+	if err := c.decode(key, v); err != nil {
+		return nil, err
+	}
+	c.store(key, v)
+	return v, nil
+}
+
+// Set stores v under key. The raw ext is only updated on Marshal.
+func (c *ExtCache) Set(key string, v interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.store(key, v)
+	if c.dirty == nil {
+		c.dirty = make(map[string]struct{})
+	}
+	c.dirty[key] = struct{}{}
+}
+
+// Marshal merges all modified values into the raw ext, returns the result and
+// releases all cached values.
+func (c *ExtCache) Marshal() (json.RawMessage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.dirty) == 0 {
+		c.release()
+		return c.raw, nil
+	}
+
+	members, err := c.parse()
+	if err != nil {
+		return nil, err
+	}
+	for key := range c.dirty {
+		if v := c.values[key]; v == nil {
+			delete(members, key)
+		} else if members[key], err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(members) == 0 {
+		c.raw = nil
+	} else if c.raw, err = json.Marshal(members); err != nil {
+		return nil, err
+	}
+
+	c.release()
+	return c.raw, nil
+}
+
+// CachedExt returns the value stored under key from the cache, decoding it into
+// T on first access. If a type is registered for the key, T must match it
+// either directly or as a pointer.
+func CachedExt[T any](c *ExtCache, key string) (T, error) {
+	var zero T
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if err := checkExt(c.kind, key, t); err != nil {
+		return zero, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := c.values[key]; ok {
+		switch x := v.(type) {
+		case nil:
+			return zero, fmt.Errorf("%w: %s", ErrExtNotFound, key)
+		case T:
+			return x, nil
+		case *T:
+			return *x, nil
+		}
+		return zero, fmt.Errorf("%w: %s.%s is %T, not %s", ErrExtTypeMismatch, c.kind, key, v, t)
+	}
+
+	v := new(T)
+	if err := c.decode(key, v); err != nil {
+		return zero, err
+	}
+	c.store(key, v)
+	return *v, nil
+}
+
+func (c *ExtCache) parse() (map[string]json.RawMessage, error) {
+	if c.members != nil {
+		return c.members, nil
+	}
+
+	members, err := extMembers(c.raw)
+	if err != nil {
+		return nil, err
+	}
+	c.members = members
+	return members, nil
+}
+
+func (c *ExtCache) decode(key string, v interface{}) error {
+	members, err := c.parse()
+	if err != nil {
+		return err
+	}
+
+	raw, ok := members[key]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrExtNotFound, key)
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func (c *ExtCache) store(key string, v interface{}) {
+	if c.values == nil {
+		c.values = make(map[string]interface{})
+	}
+	c.values[key] = v
+}
+
+func (c *ExtCache) release() {
+	c.members = nil
+	c.values = nil
+	c.dirty = nil
+}
@@ -0,0 +1,176 @@
+package openrtb_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+type testPrebidExt struct {
+	Debug     bool              `json:"debug,omitempty"`
+	Targeting map[string]string `json:"targeting,omitempty"`
+}
+
+type testDSAExt struct {
+	Required int `json:"dsarequired"`
+}
+
+func init() {
+	RegisterExt[testPrebidExt](ExtBidRequest, "prebid")
+	RegisterExt[testDSAExt](ExtRegulations, "dsa")
+}
+
+func TestGetExt(t *testing.T) {
+	ext := json.RawMessage(`{"prebid":{"debug":true},"other":1}`)
+
+	got, err := GetExt[testPrebidExt](ExtBidRequest, ext, "prebid")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp := (testPrebidExt{Debug: true}); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	if _, err := GetExt[testPrebidExt](ExtBidRequest, ext, "missing"); !errors.Is(err, ErrExtNotFound) {
+		t.Errorf("expected %v, got %v", ErrExtNotFound, err)
+	}
+	if _, err := GetExt[testPrebidExt](ExtBidRequest, nil, "prebid"); !errors.Is(err, ErrExtNotFound) {
+		t.Errorf("expected %v, got %v", ErrExtNotFound, err)
+	}
+	if _, err := GetExt[*testPrebidExt](ExtBidRequest, ext, "prebid"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if _, err := GetExt[testDSAExt](ExtBidRequest, ext, "prebid"); !errors.Is(err, ErrExtTypeMismatch) {
+		t.Errorf("expected %v, got %v", ErrExtTypeMismatch, err)
+	}
+	if _, err := GetExt[testDSAExt](ExtImpression, ext, "prebid"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestSetExt(t *testing.T) {
+	ext, err := SetExt(json.RawMessage(`{"prebid":{"debug":true},"other":1}`), "prebid", testPrebidExt{Targeting: map[string]string{"hb_pb": "1.00"}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := `{"other":1,"prebid":{"targeting":{"hb_pb":"1.00"}}}`, string(ext); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	ext, err = SetExt(ext, "other", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := `{"prebid":{"targeting":{"hb_pb":"1.00"}}}`, string(ext); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	ext, err = SetExt(ext, "prebid", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ext != nil {
+		t.Errorf("expected nil, got %s", ext)
+	}
+}
+
+func TestRegisteredExt(t *testing.T) {
+	if typ, ok := RegisteredExt(ExtRegulations, "dsa"); !ok || typ != reflect.TypeOf(testDSAExt{}) {
+		t.Errorf("expected registration, got %v", typ)
+	}
+	if _, ok := RegisteredExt(ExtBidRequest, "dsa"); ok {
+		t.Errorf("expected no registration")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic on conflicting registration")
+		}
+	}()
+	RegisterExt[testDSAExt](ExtBidRequest, "prebid")
+}
+
+func TestExtCache(t *testing.T) {
+	subject := NewExtCache(ExtBidRequest, json.RawMessage(`{"prebid":{"debug":true},"other":[1,2]}`))
+
+	v1, err := subject.Get("prebid")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp := (&testPrebidExt{Debug: true}); !reflect.DeepEqual(exp, v1) {
+		t.Errorf("expected %+v, got %+v", exp, v1)
+	}
+
+	v2, err := subject.Get("prebid")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if v1 != v2 {
+		t.Errorf("expected cached value to be returned")
+	}
+
+	typed, err := CachedExt[testPrebidExt](subject, "prebid")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !typed.Debug {
+		t.Errorf("expected debug to be set")
+	}
+
+	if _, err := CachedExt[testDSAExt](subject, "prebid"); !errors.Is(err, ErrExtTypeMismatch) {
+		t.Errorf("expected %v, got %v", ErrExtTypeMismatch, err)
+	}
+
+	other, err := subject.Get("other")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp := []interface{}{float64(1), float64(2)}; !reflect.DeepEqual(exp, *other.(*interface{})) {
+		t.Errorf("expected %v, got %v", exp, other)
+	}
+
+	typed.Debug = false
+	typed.Targeting = map[string]string{"hb_bidder": "x"}
+	subject.Set("prebid", typed)
+	subject.Set("dsa", map[string]int{"dsarequired": 1})
+
+	ext, err := subject.Marshal()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := `{"dsa":{"dsarequired":1},"other":[1,2],"prebid":{"targeting":{"hb_bidder":"x"}}}`, string(ext); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	v3, err := subject.Get("prebid")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp := (&testPrebidExt{Targeting: map[string]string{"hb_bidder": "x"}}); !reflect.DeepEqual(exp, v3) {
+		t.Errorf("expected %+v, got %+v", exp, v3)
+	}
+}
+
+func TestExtCache_delete(t *testing.T) {
+	subject := NewExtCache(ExtBidRequest, json.RawMessage(`{"prebid":{"debug":true}}`))
+	subject.Set("prebid", nil)
+
+	if _, err := CachedExt[testPrebidExt](subject, "prebid"); !errors.Is(err, ErrExtNotFound) {
+		t.Errorf("expected %v, got %v", ErrExtNotFound, err)
+	}
+	if _, err := subject.Get("prebid"); !errors.Is(err, ErrExtNotFound) {
+		t.Errorf("expected %v, got %v", ErrExtNotFound, err)
+	}
+
+	ext, err := subject.Marshal()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ext != nil {
+		t.Errorf("expected nil, got %s", ext)
+	}
+}
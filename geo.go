@@ -0,0 +1,75 @@
+package openrtb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tomlightning/openrtb/v3/iso3166"
+)
+
+// Validation errors for geo codes.
+var (
+	ErrUnknownCountry = errors.New("openrtb: unknown country code")
+	ErrUnknownRegion  = errors.New("openrtb: unknown region code")
+)
+
+// Normalize canonicalizes Country to ISO 3166-1 alpha-3 and Region to the
+// ISO 3166-2 subdivision code without country prefix, e.g. "CA" for
+// California, as used on the wire. Prefixed codes and subdivision names are
+// resolved within the country. If Region is missing, it is derived from
+// RegionFIPS104 where possible. See RegionISO for the prefixed code.
+//
+// Values which cannot be resolved are retained and reported as
+// ErrUnknownCountry or ErrUnknownRegion.
+func (g *Geo) Normalize() error {
+	var errs []error
+
+	var country string // alpha-2
+	if g.Country != "" {
+		if c, ok := iso3166.LookupCountry(g.Country); ok {
+			g.Country, country = c.Alpha3, c.Alpha2
+		} else {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownCountry, g.Country))
+		}
+	}
+
+	if g.Region == "" && g.RegionFIPS104 != "" {
+		if s, ok := iso3166.LookupFIPS(g.RegionFIPS104); ok && (country == "" || country == s.Country) {
+			g.Region = s.Code
+		} else if len(g.RegionFIPS104) != 4 || iso3166.HasFIPS(g.RegionFIPS104[:2]) {
+			errs = append(errs, fmt.Errorf("%w: FIPS 10-4 %q", ErrUnknownRegion, g.RegionFIPS104))
+		}
+	}
+
+	if g.Region != "" && len(errs) == 0 {
+		if s, ok := iso3166.LookupSubdivision(country, g.Region); ok {
+			g.Region = s.Local()
+			if country == "" {
+				c, _ := iso3166.LookupCountry(s.Country)
+				g.Country = c.Alpha3
+			}
+		} else {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownRegion, g.Region))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CountryAlpha2 returns the ISO 3166-1 alpha-2 code of Country, which may be
+// given as alpha-2 or alpha-3 code. Returns an empty string if the country is
+// unknown.
+func (g *Geo) CountryAlpha2() string {
+	c, _ := iso3166.LookupCountry(g.Country)
+	return c.Alpha2
+}
+
+// RegionISO returns the full ISO 3166-2 code of Region including country
+// prefix, e.g. "US-CA". Returns an empty string if the region is unknown.
+func (g *Geo) RegionISO() string {
+	if g.Region == "" {
+		return ""
+	}
+	s, _ := iso3166.LookupSubdivision(g.CountryAlpha2(), g.Region)
+	return s.Code
+}
@@ -0,0 +1,124 @@
+// Package geo evaluates geofences against the locations of OpenRTB requests.
+//
+// Fences are circles (center and radius) or polygons, optionally loaded from
+// GeoJSON. They are kept in a grid Index which resolves the candidate fences
+// of a location in constant time. Locations are weighted by the accuracy and
+// age of their fix, so imprecise or stale fixes can be discounted or
+// rejected.
+//
+// Polygons are evaluated in the plane of latitude and longitude, which is
+// accurate for fences of up to a few hundred kilometers. Fences crossing the
+// antimeridian are not supported.
+package geo
+
+import (
+	"math"
+)
+
+// earthRadius is the mean radius of the earth in meters.
+const earthRadius = 6371008.8
+
+// Point is a WGS 84 coordinate.
+type Point struct {
+	Lat, Lon float64
+}
+
+// Distance returns the great-circle distance in meters between p and q.
+func (p Point) Distance(q Point) float64 {
+	lat1, lat2 := radians(p.Lat), radians(q.Lat)
+	dLat, dLon := lat2-lat1, radians(q.Lon-p.Lon)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+// Rect is a bounding box.
+type Rect struct {
+	Min, Max Point
+}
+
+// Contains returns true if p is within r.
+func (r Rect) Contains(p Point) bool {
+	return p.Lat >= r.Min.Lat && p.Lat <= r.Max.Lat && p.Lon >= r.Min.Lon && p.Lon <= r.Max.Lon
+}
+
+// Fence is a geographic area.
+type Fence interface {
+	// Bounds returns the bounding box of the fence.
+	Bounds() Rect
+	// Contains returns true if p is within the fence.
+	Contains(p Point) bool
+}
+
+// Circle is a fence of all points within Radius meters of Center.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// Bounds implements Fence.
+func (c Circle) Bounds() Rect {
+	dLat := c.Radius / earthRadius * 180 / math.Pi
+	dLon := 180.0
+	if cos := math.Cos(radians(c.Center.Lat)); cos > 1e-9 {
+		dLon = math.Min(180, dLat/cos)
+	}
+	return Rect{
+		Min: Point{Lat: math.Max(-90, c.Center.Lat-dLat), Lon: math.Max(-180, c.Center.Lon-dLon)},
+		Max: Point{Lat: math.Min(90, c.Center.Lat+dLat), Lon: math.Min(180, c.Center.Lon+dLon)},
+	}
+}
+
+// Contains implements Fence.
+func (c Circle) Contains(p Point) bool {
+	return c.Center.Distance(p) <= c.Radius
+}
+
+// Polygon is a fence bounded by an outer ring, excluding any holes. Rings
+// are closed implicitly.
+type Polygon struct {
+	Outer []Point
+	Holes [][]Point
+}
+
+// Bounds implements Fence.
+func (g Polygon) Bounds() Rect {
+	if len(g.Outer) == 0 {
+		return Rect{}
+	}
+
+	r := Rect{Min: g.Outer[0], Max: g.Outer[0]}
+	for _, p := range g.Outer[1:] {
+		r.Min.Lat, r.Min.Lon = math.Min(r.Min.Lat, p.Lat), math.Min(r.Min.Lon, p.Lon)
+		r.Max.Lat, r.Max.Lon = math.Max(r.Max.Lat, p.Lat), math.Max(r.Max.Lon, p.Lon)
+	}
+	return r
+}
+
+// Contains implements Fence.
+func (g Polygon) Contains(p Point) bool {
+	if !inRing(g.Outer, p) {
+		return false
+	}
+	for _, hole := range g.Holes {
+		if inRing(hole, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// inRing tests p against ring using the even-odd rule.
+func inRing(ring []Point, p Point) bool {
+	in := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lon < (b.Lon-a.Lon)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			in = !in
+		}
+	}
+	return in
+}
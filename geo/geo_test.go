@@ -0,0 +1,185 @@
+package geo_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/tomlightning/openrtb/v3"
+
+	. "github.com/tomlightning/openrtb/v3/geo"
+)
+
+func loadIndex(t testing.TB, opts *Options) *Index {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", "fences.geojson"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer f.Close()
+
+	subject := NewIndex(opts)
+	if err := subject.LoadGeoJSON(f); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return subject
+}
+
+func TestPoint_Distance(t *testing.T) {
+	jfk, lhr := Point{Lat: 40.6413, Lon: -73.7781}, Point{Lat: 51.4700, Lon: -0.4543}
+	if exp, got := 5540e3, jfk.Distance(lhr); math.Abs(exp-got) > 5e3 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got := jfk.Distance(jfk); got != 0 {
+		t.Errorf("expected 0, got %v", got)
+	}
+}
+
+func TestIndex_Contains(t *testing.T) {
+	subject := loadIndex(t, nil)
+	if exp, got := 4, subject.Len(); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	for _, tc := range []struct {
+		p   Point
+		exp []string
+	}{
+		{Point{Lat: 40.7580, Lon: -73.9855}, []string{"times-square"}}, // inside the hole of midtown
+		{Point{Lat: 40.7615, Lon: -73.9835}, []string{"times-square", "midtown"}},
+		{Point{Lat: 40.7450, Lon: -73.9700}, []string{"midtown"}},
+		{Point{Lat: 40.6413, Lon: -73.7781}, []string{"42"}},
+		{Point{Lat: 40.7769, Lon: -73.8740}, []string{"42"}},
+		{Point{Lat: 40.7000, Lon: -73.9000}, nil},
+		{Point{Lat: -33.8688, Lon: 151.2093}, nil},
+	} {
+		got := subject.Contains(nil, tc.p)
+		sort.Strings(got)
+		sort.Strings(tc.exp)
+		if !reflect.DeepEqual(tc.exp, got) {
+			t.Errorf("%+v: expected %v, got %v", tc.p, tc.exp, got)
+		}
+	}
+}
+
+func TestIndex_MatchGeo(t *testing.T) {
+	subject := loadIndex(t, &Options{MinConfidence: 0.1})
+
+	geo := &openrtb.Geo{Latitude: 40.7450, Longitude: -73.9700, Type: openrtb.LocationTypeGPS}
+	if exp, got := []Match{{ID: "midtown", Confidence: 1}}, subject.MatchGeo(nil, geo); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	// discounted by accuracy
+	geo.Accuracy = 2550
+	if got := subject.MatchGeo(nil, geo); len(got) != 1 || math.Abs(got[0].Confidence-0.5) > 1e-9 {
+		t.Errorf("expected confidence 0.5, got %+v", got)
+	}
+
+	// discounted by accuracy and age
+	geo.LastFix = int((DefaultFreshAge + (DefaultMaxAge-DefaultFreshAge)/2).Seconds())
+	if got := subject.MatchGeo(nil, geo); len(got) != 1 || math.Abs(got[0].Confidence-0.25) > 1e-9 {
+		t.Errorf("expected confidence 0.25, got %+v", got)
+	}
+
+	// rejected
+	for _, g := range []*openrtb.Geo{
+		{Latitude: 40.7450, Longitude: -73.9700, Accuracy: 20000},
+		{Latitude: 40.7450, Longitude: -73.9700, LastFix: 86401},
+		{Latitude: 40.7450, Longitude: -73.9700, Accuracy: 4600},
+		{Country: "USA"},
+		nil,
+	} {
+		if got := subject.MatchGeo(nil, g); len(got) != 0 {
+			t.Errorf("%+v: expected no matches, got %+v", g, got)
+		}
+	}
+}
+
+func TestIndex_MatchRequest(t *testing.T) {
+	subject := loadIndex(t, nil)
+
+	req := &openrtb.BidRequest{
+		Device: &openrtb.Device{Geo: &openrtb.Geo{Latitude: 40.7450, Longitude: -73.9700, Accuracy: 10000}},
+		User:   &openrtb.User{Geo: &openrtb.Geo{Latitude: 40.6413, Longitude: -73.7781}},
+	}
+	if exp, got := []Match{{ID: "42", Confidence: 1}}, subject.MatchRequest(nil, req); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	req.Device.Geo.Accuracy = 50
+	if exp, got := []Match{{ID: "midtown", Confidence: 1}}, subject.MatchRequest(nil, req); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+}
+
+func TestIndex_largeFences(t *testing.T) {
+	subject := NewIndex(&Options{CellSize: 0.01})
+	subject.AddCircle("usa", Point{Lat: 39.8, Lon: -98.6}, 2000e3)
+	subject.AddCircle("kansas", Point{Lat: 38.5, Lon: -98.0}, 300e3)
+
+	got := subject.Contains(nil, Point{Lat: 39.0, Lon: -97.5})
+	sort.Strings(got)
+	if exp := []string{"kansas", "usa"}; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestIndex_LoadGeoJSON_invalid(t *testing.T) {
+	for name, doc := range map[string]string{
+		"syntax":      `{"type": "FeatureCollection"`,
+		"type":        `{"type": "Polygon", "coordinates": []}`,
+		"no id":       `{"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 0]}, "properties": {"radius": 1}}`,
+		"no radius":   `{"type": "Feature", "id": "a", "geometry": {"type": "Point", "coordinates": [0, 0]}}`,
+		"no geometry": `{"type": "Feature", "id": "a"}`,
+		"ring":        `{"type": "Feature", "id": "a", "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 1], [0, 0]]]}}`,
+		"unsupported": `{"type": "Feature", "id": "a", "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}}`,
+	} {
+		subject := NewIndex(nil)
+		if err := subject.LoadGeoJSON(strings.NewReader(doc)); !errors.Is(err, ErrInvalidGeoJSON) {
+			t.Errorf("%s: expected %v, got %v", name, ErrInvalidGeoJSON, err)
+		}
+		if subject.Len() != 0 {
+			t.Errorf("%s: expected no fences, got %d", name, subject.Len())
+		}
+	}
+}
+
+func BenchmarkIndex_MatchGeo(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	subject := NewIndex(nil)
+	for i := 0; i < 10000; i++ {
+		center := Point{Lat: 25 + rnd.Float64()*24, Lon: -124 + rnd.Float64()*57}
+		if i%2 == 0 {
+			subject.AddCircle(fmt.Sprint(i), center, 200+rnd.Float64()*5000)
+			continue
+		}
+		d := 0.01 + rnd.Float64()*0.05
+		subject.Add(fmt.Sprint(i), Polygon{Outer: []Point{
+			{Lat: center.Lat - d, Lon: center.Lon - d},
+			{Lat: center.Lat - d, Lon: center.Lon + d},
+			{Lat: center.Lat + d, Lon: center.Lon + d},
+			{Lat: center.Lat + d, Lon: center.Lon - d},
+		}})
+	}
+
+	geos := make([]*openrtb.Geo, 1024)
+	for i := range geos {
+		geos[i] = &openrtb.Geo{Latitude: 25 + rnd.Float64()*24, Longitude: -124 + rnd.Float64()*57, Accuracy: 50}
+	}
+
+	var dst []Match
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst = subject.MatchGeo(dst[:0], geos[i%len(geos)])
+	}
+}
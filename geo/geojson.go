@@ -0,0 +1,147 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/goccy/go-json"
+)
+
+// ErrInvalidGeoJSON is returned when GeoJSON input cannot be loaded.
+var ErrInvalidGeoJSON = errors.New("geo: invalid GeoJSON")
+
+type geoJSON struct {
+	Type        string          `json:"type"`
+	ID          json.RawMessage `json:"id"`
+	Properties  map[string]any  `json:"properties"`
+	Geometry    *geoJSON        `json:"geometry"`
+	Features    []geoJSON       `json:"features"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// LoadGeoJSON adds the features of a GeoJSON FeatureCollection or Feature.
+// Polygon and MultiPolygon geometries are added as polygons, Point
+// geometries are added as circles with the radius in meters given by the
+// "radius" property. Fences are identified by the feature ID or, if missing,
+// the "id" property.
+func (ix *Index) LoadGeoJSON(r io.Reader) error {
+	var doc geoJSON
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+	}
+
+	var features []geoJSON
+	switch doc.Type {
+	case "FeatureCollection":
+		features = doc.Features
+	case "Feature":
+		features = []geoJSON{doc}
+	default:
+		return fmt.Errorf("%w: unsupported type %q", ErrInvalidGeoJSON, doc.Type)
+	}
+
+	type fence struct {
+		id    string
+		fence Fence
+	}
+	var fences []fence
+	for i, f := range features {
+		id := featureID(&f)
+		if id == "" {
+			return fmt.Errorf("%w: feature %d has no ID", ErrInvalidGeoJSON, i)
+		}
+		if f.Geometry == nil {
+			return fmt.Errorf("%w: feature %q has no geometry", ErrInvalidGeoJSON, id)
+		}
+
+		geom := f.Geometry
+		switch geom.Type {
+		case "Point":
+			var pos []float64
+			if err := json.Unmarshal(geom.Coordinates, &pos); err != nil || len(pos) < 2 {
+				return fmt.Errorf("%w: feature %q has invalid coordinates", ErrInvalidGeoJSON, id)
+			}
+			radius, _ := f.Properties["radius"].(float64)
+			if radius <= 0 {
+				return fmt.Errorf("%w: feature %q has no radius", ErrInvalidGeoJSON, id)
+			}
+			fences = append(fences, fence{id, Circle{Center: Point{Lat: pos[1], Lon: pos[0]}, Radius: radius}})
+		case "Polygon":
+			var rings [][][]float64
+			if err := json.Unmarshal(geom.Coordinates, &rings); err != nil {
+				return fmt.Errorf("%w: feature %q has invalid coordinates", ErrInvalidGeoJSON, id)
+			}
+			poly, err := polygon(rings)
+			if err != nil {
+				return fmt.Errorf("%w: feature %q: %v", ErrInvalidGeoJSON, id, err)
+			}
+			fences = append(fences, fence{id, poly})
+		case "MultiPolygon":
+			var polys [][][][]float64
+			if err := json.Unmarshal(geom.Coordinates, &polys); err != nil {
+				return fmt.Errorf("%w: feature %q has invalid coordinates", ErrInvalidGeoJSON, id)
+			}
+			for _, rings := range polys {
+				poly, err := polygon(rings)
+				if err != nil {
+					return fmt.Errorf("%w: feature %q: %v", ErrInvalidGeoJSON, id, err)
+				}
+				fences = append(fences, fence{id, poly})
+			}
+		default:
+			return fmt.Errorf("%w: feature %q has unsupported geometry %q", ErrInvalidGeoJSON, id, geom.Type)
+		}
+	}
+
+	for _, f := range fences {
+		ix.Add(f.id, f.fence)
+	}
+	return nil
+}
+
+func featureID(f *geoJSON) string {
+	var v any
+	if len(f.ID) != 0 {
+		_ = json.Unmarshal(f.ID, &v)
+	}
+	if v == nil {
+		v = f.Properties["id"]
+	}
+
+	switch id := v.(type) {
+	case string:
+		return id
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64)
+	}
+	return ""
+}
+
+func polygon(rings [][][]float64) (Polygon, error) {
+	if len(rings) == 0 {
+		return Polygon{}, errors.New("polygon has no rings")
+	}
+
+	var poly Polygon
+	for i, ring := range rings {
+		if len(ring) < 4 {
+			return poly, errors.New("ring has fewer than 4 positions")
+		}
+
+		pts := make([]Point, 0, len(ring))
+		for _, pos := range ring {
+			if len(pos) < 2 {
+				return poly, errors.New("invalid position")
+			}
+			pts = append(pts, Point{Lat: pos[1], Lon: pos[0]})
+		}
+		if i == 0 {
+			poly.Outer = pts
+		} else {
+			poly.Holes = append(poly.Holes, pts)
+		}
+	}
+	return poly, nil
+}
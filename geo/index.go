@@ -0,0 +1,217 @@
+package geo
+
+import (
+	"math"
+	"time"
+
+	"github.com/tomlightning/openrtb/v3"
+)
+
+// Default index and discount settings.
+const (
+	DefaultCellSize     = 0.1 // degrees, approx. 11 km
+	DefaultGoodAccuracy = 100
+	DefaultMaxAccuracy  = 5000
+	DefaultFreshAge     = 10 * time.Minute
+	DefaultMaxAge       = 24 * time.Hour
+)
+
+// maxCellsPerFence limits the number of grid cells a fence is registered in.
+// Larger fences are checked for every lookup.
+const maxCellsPerFence = 1024
+
+// Options configure an Index.
+type Options struct {
+	// CellSize is the edge length of grid cells in degrees.
+	// Default: DefaultCellSize.
+	CellSize float64
+
+	// GoodAccuracy and MaxAccuracy, in meters, define how the accuracy of a
+	// fix is discounted. Fixes at least as accurate as GoodAccuracy are fully
+	// trusted, fixes less accurate than MaxAccuracy are rejected, confidence
+	// decreases linearly in between. Fixes without accuracy are trusted.
+	// Default: DefaultGoodAccuracy and DefaultMaxAccuracy.
+	GoodAccuracy, MaxAccuracy float64
+
+	// FreshAge and MaxAge define how the age of a fix is discounted, like
+	// GoodAccuracy and MaxAccuracy. Fixes without age are trusted.
+	// Default: DefaultFreshAge and DefaultMaxAge.
+	FreshAge, MaxAge time.Duration
+
+	// MinConfidence is the confidence required for a match. Default: any
+	// confidence above zero.
+	MinConfidence float64
+}
+
+func (o *Options) norm() Options {
+	var opt Options
+	if o != nil {
+		opt = *o
+	}
+	if opt.CellSize <= 0 {
+		opt.CellSize = DefaultCellSize
+	}
+	if opt.GoodAccuracy <= 0 {
+		opt.GoodAccuracy = DefaultGoodAccuracy
+	}
+	if opt.MaxAccuracy <= 0 {
+		opt.MaxAccuracy = DefaultMaxAccuracy
+	}
+	if opt.FreshAge <= 0 {
+		opt.FreshAge = DefaultFreshAge
+	}
+	if opt.MaxAge <= 0 {
+		opt.MaxAge = DefaultMaxAge
+	}
+	return opt
+}
+
+// Match is a fence matched by a location.
+type Match struct {
+	ID         string  // Fence ID
+	Confidence float64 // Confidence of the location, between 0 and 1
+}
+
+type entry struct {
+	id     string
+	fence  Fence
+	bounds Rect
+}
+
+// Index is a spatial index of fences. Fences must be added before the index
+// is queried, lookups are safe for concurrent use.
+type Index struct {
+	opt     Options
+	entries []entry
+	cells   map[cellKey][]int32
+	large   []int32
+}
+
+type cellKey struct{ lat, lon int32 }
+
+// NewIndex creates an empty Index.
+func NewIndex(opts *Options) *Index {
+	return &Index{opt: opts.norm(), cells: make(map[cellKey][]int32)}
+}
+
+// Len returns the number of fences.
+func (ix *Index) Len() int {
+	return len(ix.entries)
+}
+
+// Add adds a fence. Multiple fences may share the same ID.
+func (ix *Index) Add(id string, fence Fence) {
+	n := int32(len(ix.entries))
+	bounds := fence.Bounds()
+	ix.entries = append(ix.entries, entry{id: id, fence: fence, bounds: bounds})
+
+	lo, hi := ix.cell(bounds.Min), ix.cell(bounds.Max)
+	if cells := (int64(hi.lat-lo.lat) + 1) * (int64(hi.lon-lo.lon) + 1); cells > maxCellsPerFence {
+		ix.large = append(ix.large, n)
+		return
+	}
+	for lat := lo.lat; lat <= hi.lat; lat++ {
+		for lon := lo.lon; lon <= hi.lon; lon++ {
+			key := cellKey{lat, lon}
+			ix.cells[key] = append(ix.cells[key], n)
+		}
+	}
+}
+
+// AddCircle adds a circle of radius meters around center.
+func (ix *Index) AddCircle(id string, center Point, radius float64) {
+	ix.Add(id, Circle{Center: center, Radius: radius})
+}
+
+func (ix *Index) cell(p Point) cellKey {
+	return cellKey{
+		lat: int32(math.Floor(p.Lat / ix.opt.CellSize)),
+		lon: int32(math.Floor(p.Lon / ix.opt.CellSize)),
+	}
+}
+
+// Contains appends the IDs of all fences containing p to dst. Each ID is
+// reported once.
+func (ix *Index) Contains(dst []string, p Point) []string {
+	start := len(dst)
+	test := func(n int32) {
+		e := &ix.entries[n]
+		if !e.bounds.Contains(p) || !e.fence.Contains(p) {
+			return
+		}
+		for _, id := range dst[start:] {
+			if id == e.id {
+				return
+			}
+		}
+		dst = append(dst, e.id)
+	}
+
+	for _, n := range ix.cells[ix.cell(p)] {
+		test(n)
+	}
+	for _, n := range ix.large {
+		test(n)
+	}
+	return dst
+}
+
+// Confidence returns the confidence in the location of geo, between 0 and 1,
+// based on the accuracy and age of the fix. Returns 0 if geo has no location.
+func (ix *Index) Confidence(geo *openrtb.Geo) float64 {
+	if geo == nil || (geo.Latitude == 0 && geo.Longitude == 0) {
+		return 0
+	}
+
+	opt := &ix.opt
+	return discount(float64(geo.Accuracy), opt.GoodAccuracy, opt.MaxAccuracy) *
+		discount(float64(geo.LastFix), opt.FreshAge.Seconds(), opt.MaxAge.Seconds())
+}
+
+// discount returns 1 for v <= good, 0 for v > max and interpolates linearly
+// in between.
+func discount(v, good, max float64) float64 {
+	switch {
+	case v <= good:
+		return 1
+	case v > max:
+		return 0
+	case max <= good:
+		return 1
+	}
+	return 1 - (v-good)/(max-good)
+}
+
+// MatchGeo returns the fences containing the location of geo, unless the
+// confidence in the location is too low.
+func (ix *Index) MatchGeo(dst []Match, geo *openrtb.Geo) []Match {
+	conf := ix.Confidence(geo)
+	if conf <= 0 || conf < ix.opt.MinConfidence {
+		return dst
+	}
+
+	var buf [8]string
+	for _, id := range ix.Contains(buf[:0], Point{Lat: geo.Latitude, Lon: geo.Longitude}) {
+		dst = append(dst, Match{ID: id, Confidence: conf})
+	}
+	return dst
+}
+
+// MatchRequest matches the device location of req or, if the device has no
+// usable location, the user location.
+func (ix *Index) MatchRequest(dst []Match, req *openrtb.BidRequest) []Match {
+	var geos [2]*openrtb.Geo
+	if req.Device != nil {
+		geos[0] = req.Device.Geo
+	}
+	if req.User != nil {
+		geos[1] = req.User.Geo
+	}
+
+	for _, geo := range geos {
+		if conf := ix.Confidence(geo); conf > 0 && conf >= ix.opt.MinConfidence {
+			return ix.MatchGeo(dst, geo)
+		}
+	}
+	return dst
+}
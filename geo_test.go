@@ -0,0 +1,94 @@
+package openrtb_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestGeo_Normalize(t *testing.T) {
+	for _, tc := range []struct {
+		subject Geo
+		exp     Geo
+	}{
+		{Geo{Country: "US", Region: "CA"}, Geo{Country: "USA", Region: "CA"}},
+		{Geo{Country: "usa", Region: "us-ca"}, Geo{Country: "USA", Region: "CA"}},
+		{Geo{Country: "DE", Region: "Bayern"}, Geo{Country: "DEU", Region: "BY"}},
+		{Geo{Region: "GB-ENG"}, Geo{Country: "GBR", Region: "ENG"}},
+		{Geo{Country: "CAN", RegionFIPS104: "CA08"}, Geo{Country: "CAN", Region: "ON", RegionFIPS104: "CA08"}},
+		{Geo{RegionFIPS104: "GM16"}, Geo{Country: "DEU", Region: "BE", RegionFIPS104: "GM16"}},
+		{Geo{Country: "FR", Region: "idf"}, Geo{Country: "FRA", Region: "IDF"}},
+		{Geo{Country: "FRA", Region: "FR-IDF"}, Geo{Country: "FRA", Region: "IDF"}},
+		{Geo{Country: "FRA", RegionFIPS104: "FRA8"}, Geo{Country: "FRA", RegionFIPS104: "FRA8"}},
+		{Geo{}, Geo{}},
+	} {
+		subject := tc.subject
+		if err := subject.Normalize(); err != nil {
+			t.Errorf("%+v: expected no error, got %v", tc.subject, err)
+		}
+		if exp, got := tc.exp, subject; exp.Country != got.Country || exp.Region != got.Region || exp.RegionFIPS104 != got.RegionFIPS104 {
+			t.Errorf("expected %+v, got %+v", exp, got)
+		}
+	}
+}
+
+func TestGeo_Normalize_unknown(t *testing.T) {
+	for _, tc := range []struct {
+		subject Geo
+		exp     error
+	}{
+		{Geo{Country: "UK"}, ErrUnknownCountry},
+		{Geo{Country: "US", Region: "XX"}, ErrUnknownRegion},
+		{Geo{Country: "FR", Region: "DE-BY"}, ErrUnknownRegion},
+		{Geo{Country: "FR", Region: "XX"}, ErrUnknownRegion},
+		{Geo{Country: "VA", Region: "01"}, ErrUnknownRegion},
+		{Geo{Region: "CA"}, ErrUnknownRegion},
+		{Geo{Country: "US", RegionFIPS104: "US03"}, ErrUnknownRegion},
+		{Geo{RegionFIPS104: "California"}, ErrUnknownRegion},
+	} {
+		subject := tc.subject
+		if err := subject.Normalize(); !errors.Is(err, tc.exp) {
+			t.Errorf("%+v: expected %v, got %v", tc.subject, tc.exp, err)
+		}
+	}
+
+	subject := Geo{Country: "UK", Region: "ENG"}
+	_ = subject.Normalize()
+	if exp, got := "UK", subject.Country; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestGeo_CountryAlpha2(t *testing.T) {
+	for country, exp := range map[string]string{
+		"USA": "US",
+		"us":  "US",
+		"GBR": "GB",
+		"":    "",
+		"XYZ": "",
+	} {
+		subject := Geo{Country: country}
+		if got := subject.CountryAlpha2(); exp != got {
+			t.Errorf("%q: expected %v, got %v", country, exp, got)
+		}
+	}
+}
+
+func TestGeo_RegionISO(t *testing.T) {
+	for _, tc := range []struct {
+		subject Geo
+		exp     string
+	}{
+		{Geo{Country: "USA", Region: "CA"}, "US-CA"},
+		{Geo{Country: "US", Region: "US-CA"}, "US-CA"},
+		{Geo{Country: "DEU", Region: "Bayern"}, "DE-BY"},
+		{Geo{Country: "USA", Region: "XX"}, ""},
+		{Geo{Region: "CA"}, ""},
+		{Geo{}, ""},
+	} {
+		if got := tc.subject.RegionISO(); tc.exp != got {
+			t.Errorf("%+v: expected %v, got %v", tc.subject, tc.exp, got)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Package geoip enriches OpenRTB device geolocation from local MaxMind DB
+// (.mmdb) files, such as GeoIP2 or GeoLite2 City databases.
+//
+// Lookups are performed entirely offline with the Reader implemented in this
+// package.
+package geoip
+
+import (
+	"net/netip"
+	"strconv"
+
+	"github.com/tomlightning/openrtb/v3"
+	"github.com/tomlightning/openrtb/v3/iso3166"
+)
+
+// City is the subset of a GeoIP2 City record relevant to OpenRTB.
+type City struct {
+	Country        string  // ISO 3166-1 alpha-2 country code
+	Region         string  // ISO 3166-2 code of the most general subdivision, without country prefix
+	City           string  // City name
+	Metro          int     // Nielsen DMA code (US only)
+	ZIP            string  // Postal code
+	Latitude       float64 // Approximate latitude
+	Longitude      float64 // Approximate longitude
+	AccuracyRadius int     // Accuracy radius in kilometers
+}
+
+// LookupCity looks up addr and returns the City record in the given
+// language, defaulting to English. Returns nil if no record exists.
+func (r *Reader) LookupCity(addr netip.Addr, lang string) (*City, error) {
+	raw, err := r.Lookup(addr)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	if lang == "" {
+		lang = "en"
+	}
+
+	rec, _ := raw.(map[string]any)
+	city := &City{
+		Country:        asString(lookup(rec, "country", "iso_code")),
+		City:           asString(lookup(rec, "city", "names", lang)),
+		Metro:          int(asUint(lookup(rec, "location", "metro_code"))),
+		ZIP:            asString(lookup(rec, "postal", "code")),
+		AccuracyRadius: int(asUint(lookup(rec, "location", "accuracy_radius"))),
+	}
+	if city.Country == "" {
+		city.Country = asString(lookup(rec, "registered_country", "iso_code"))
+	}
+	if subs, ok := lookup(rec, "subdivisions").([]any); ok && len(subs) != 0 {
+		sub, _ := subs[0].(map[string]any)
+		city.Region = asString(sub["iso_code"])
+	}
+	city.Latitude, _ = lookup(rec, "location", "latitude").(float64)
+	city.Longitude, _ = lookup(rec, "location", "longitude").(float64)
+	return city, nil
+}
+
+// Enrich looks up the device's IP address, preferring IP over IPv6, and
+// populates missing Geo fields. Country and Region are set to the ISO 3166-1
+// alpha-3 code and the ISO 3166-2 code without country prefix, see
+// Geo.Normalize. Latitude and longitude are only set if both are missing. Type and IPService are set to LocationTypeIP and
+// IPLocationMaxMind unless already present.
+//
+// Geo objects sourced from GPS or provided by the user are never modified.
+// Returns true if dev was modified.
+func (r *Reader) Enrich(dev *openrtb.Device) (bool, error) {
+	if dev == nil {
+		return false, nil
+	}
+	if geo := dev.Geo; geo != nil && (geo.Type == openrtb.LocationTypeGPS || geo.Type == openrtb.LocationTypeUser) {
+		return false, nil
+	}
+
+	v4, v6, err := dev.IPAddrs()
+	if err != nil {
+		return false, err
+	}
+	addr := v4
+	if !addr.IsValid() {
+		addr = v6
+	}
+	if !addr.IsValid() {
+		return false, nil
+	}
+
+	city, err := r.LookupCity(addr, "")
+	if err != nil || city == nil {
+		return false, err
+	}
+
+	geo := dev.Geo
+	if geo == nil {
+		geo = new(openrtb.Geo)
+	}
+
+	changed := false
+	setString := func(dst *string, val string) {
+		if *dst == "" && val != "" {
+			*dst, changed = val, true
+		}
+	}
+	if c, ok := iso3166.LookupCountry(city.Country); ok {
+		setString(&geo.Country, c.Alpha3)
+		setString(&geo.Region, city.Region)
+	}
+	setString(&geo.City, city.City)
+	setString(&geo.ZIP, city.ZIP)
+	if city.Metro != 0 {
+		setString(&geo.Metro, strconv.Itoa(city.Metro))
+	}
+	if geo.Latitude == 0 && geo.Longitude == 0 && (city.Latitude != 0 || city.Longitude != 0) {
+		geo.Latitude, geo.Longitude = city.Latitude, city.Longitude
+		geo.Accuracy = city.AccuracyRadius * 1000
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+
+	if geo.Type == openrtb.LocationTypeUnknown {
+		geo.Type = openrtb.LocationTypeIP
+	}
+	if geo.IPService == openrtb.IPLocationUnknown {
+		geo.IPService = openrtb.IPLocationMaxMind
+	}
+	dev.Geo = geo
+	return true, nil
+}
+
+func lookup(v any, path ...string) any {
+	for _, key := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
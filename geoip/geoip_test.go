@@ -0,0 +1,248 @@
+package geoip_test
+
+import (
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tomlightning/openrtb/v3"
+
+	. "github.com/tomlightning/openrtb/v3/geoip"
+)
+
+func cityRecord(country, region, city, zip string, metro uint16, lat, lon float64) map[string]any {
+	rec := map[string]any{
+		"city":    map[string]any{"names": map[string]any{"en": city, "de": city + " (de)"}},
+		"country": map[string]any{"iso_code": country, "geoname_id": uint32(6252001)},
+		"location": map[string]any{
+			"latitude":        lat,
+			"longitude":       lon,
+			"accuracy_radius": uint16(20),
+			"time_zone":       "America/Los_Angeles",
+		},
+		"postal":       map[string]any{"code": zip},
+		"subdivisions": []any{map[string]any{"iso_code": region}},
+	}
+	if metro != 0 {
+		rec["location"].(map[string]any)["metro_code"] = metro
+	}
+	return rec
+}
+
+func testDB(t *testing.T, ipVersion, recordSize int) *Reader {
+	t.Helper()
+
+	w := newMMDBWriter(ipVersion, recordSize)
+	w.Insert("123.145.167.0/24", cityRecord("US", "CA", "Los Angeles", "90049", 803, 34.0544, -118.2441))
+	off := w.Insert("81.2.69.0/24", cityRecord("GB", "ENG", "London", "SW1A", 0, 51.5142, -0.0931))
+	w.Insert("2.125.160.0/20", mmdbPointer(off))
+	w.Insert("89.160.20.0/24", map[string]any{
+		"registered_country": map[string]any{"iso_code": "SE"},
+		"flags":              []any{true, false, int32(-7), uint64(1) << 40, "long " + strings.Repeat("x", 300)},
+	})
+	if ipVersion == 6 {
+		w.Insert("2a02:d140::/32", cityRecord("DE", "BE", "Berlin", "10115", 0, 52.52, 13.405))
+	}
+
+	subject, err := NewReader(w.Bytes())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return subject
+}
+
+func TestReader_Metadata(t *testing.T) {
+	subject := testDB(t, 6, 28)
+
+	meta := subject.Metadata
+	if exp, got := "Test-City", meta.DatabaseType; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := uint(6), meta.IPVersion; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := uint(28), meta.RecordSize; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := []string{"en"}, meta.Languages; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := map[string]string{"en": "Test database"}, meta.Description; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := uint64(1700000000), meta.BuildEpoch; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestReader_LookupCity(t *testing.T) {
+	for _, tc := range []struct{ ipVersion, recordSize int }{{4, 24}, {6, 24}, {6, 28}, {6, 32}} {
+		subject := testDB(t, tc.ipVersion, tc.recordSize)
+
+		got, err := subject.LookupCity(netip.MustParseAddr("123.145.167.189"), "")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		exp := &City{
+			Country:        "US",
+			Region:         "CA",
+			City:           "Los Angeles",
+			Metro:          803,
+			ZIP:            "90049",
+			Latitude:       34.0544,
+			Longitude:      -118.2441,
+			AccuracyRadius: 20,
+		}
+		if !reflect.DeepEqual(exp, got) {
+			t.Errorf("%d/%d: expected %+v, got %+v", tc.ipVersion, tc.recordSize, exp, got)
+		}
+
+		// resolved through a pointer
+		got, err = subject.LookupCity(netip.MustParseAddr("2.125.160.216"), "de")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if exp, got := "London (de)", got.City; exp != got {
+			t.Errorf("%d/%d: expected %v, got %v", tc.ipVersion, tc.recordSize, exp, got)
+		}
+
+		// not found
+		if got, err := subject.LookupCity(netip.MustParseAddr("8.8.8.8"), ""); err != nil || got != nil {
+			t.Errorf("%d/%d: expected no result, got %+v, %v", tc.ipVersion, tc.recordSize, got, err)
+		}
+	}
+}
+
+func TestReader_Lookup(t *testing.T) {
+	subject := testDB(t, 6, 24)
+
+	got, err := subject.Lookup(netip.MustParseAddr("::ffff:89.160.20.112"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	exp := map[string]any{
+		"registered_country": map[string]any{"iso_code": "SE"},
+		"flags":              []any{true, false, int64(-7), uint64(1) << 40, "long " + strings.Repeat("x", 300)},
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	city, err := subject.LookupCity(netip.MustParseAddr("89.160.20.112"), "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := "SE", city.Country; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	city, err = subject.LookupCity(netip.MustParseAddr("2a02:d140:1::1"), "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := "Berlin", city.City; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	if _, err := testDB(t, 4, 24).Lookup(netip.MustParseAddr("2a02:d140:1::1")); !errors.Is(err, ErrIPVersion) {
+		t.Errorf("expected %v, got %v", ErrIPVersion, err)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "test.mmdb")
+	w := newMMDBWriter(6, 24)
+	w.Insert("123.145.167.0/24", cityRecord("US", "CA", "Los Angeles", "90049", 803, 34.0544, -118.2441))
+	if err := os.WriteFile(name, w.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	subject, err := Open(name)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := "Test-City", subject.Metadata.DatabaseType; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestNewReader_invalid(t *testing.T) {
+	buf := newMMDBWriter(6, 24).Bytes()
+	for name, data := range map[string][]byte{
+		"empty":     nil,
+		"no marker": []byte("not a database"),
+		"truncated": buf[:len(buf)-10],
+		"tree size": append([]byte(nil), buf[6+16:]...),
+	} {
+		if _, err := NewReader(data); !errors.Is(err, ErrInvalidDatabase) {
+			t.Errorf("%s: expected %v, got %v", name, ErrInvalidDatabase, err)
+		}
+	}
+}
+
+func TestReader_Enrich(t *testing.T) {
+	subject := testDB(t, 6, 24)
+
+	dev := &openrtb.Device{IP: "123.145.167.189"}
+	if ok, err := subject.Enrich(dev); err != nil || !ok {
+		t.Fatalf("expected enrichment, got %v, %v", ok, err)
+	}
+	exp := &openrtb.Geo{
+		Country:   "USA",
+		Region:    "CA",
+		City:      "Los Angeles",
+		Metro:     "803",
+		ZIP:       "90049",
+		Latitude:  34.0544,
+		Longitude: -118.2441,
+		Accuracy:  20000,
+		Type:      openrtb.LocationTypeIP,
+		IPService: openrtb.IPLocationMaxMind,
+	}
+	if got := dev.Geo; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	// partial geo is completed, existing values are retained
+	dev = &openrtb.Device{IPv6: "2a02:d140:1::1", Geo: &openrtb.Geo{Country: "DEU", City: "Berlin-Mitte", Latitude: 52.53, Longitude: 13.4}}
+	if ok, err := subject.Enrich(dev); err != nil || !ok {
+		t.Fatalf("expected enrichment, got %v, %v", ok, err)
+	}
+	exp = &openrtb.Geo{
+		Country:   "DEU",
+		Region:    "BE",
+		City:      "Berlin-Mitte",
+		ZIP:       "10115",
+		Latitude:  52.53,
+		Longitude: 13.4,
+		Type:      openrtb.LocationTypeIP,
+		IPService: openrtb.IPLocationMaxMind,
+	}
+	if got := dev.Geo; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	// GPS data is never touched
+	gps := &openrtb.Geo{Latitude: 35.01, Longitude: -115.12, Type: openrtb.LocationTypeGPS}
+	dev = &openrtb.Device{IP: "123.145.167.189", Geo: gps}
+	if ok, err := subject.Enrich(dev); err != nil || ok {
+		t.Errorf("expected no enrichment, got %v, %v", ok, err)
+	}
+	if exp, got := (&openrtb.Geo{Latitude: 35.01, Longitude: -115.12, Type: openrtb.LocationTypeGPS}), dev.Geo; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	// unknown IPs
+	dev = &openrtb.Device{IP: "8.8.8.8"}
+	if ok, err := subject.Enrich(dev); err != nil || ok || dev.Geo != nil {
+		t.Errorf("expected no enrichment, got %v, %v, %+v", ok, err, dev.Geo)
+	}
+
+	if _, err := subject.Enrich(&openrtb.Device{IP: "junk"}); !errors.Is(err, openrtb.ErrInvalidDeviceIP) {
+		t.Errorf("expected %v, got %v", openrtb.ErrInvalidDeviceIP, err)
+	}
+}
@@ -0,0 +1,368 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/netip"
+	"os"
+)
+
+// Errors returned by Reader.
+var (
+	ErrInvalidDatabase = errors.New("geoip: invalid database")
+	ErrIPVersion       = errors.New("geoip: IPv6 lookup in IPv4 database")
+)
+
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// metadataMaxSize bounds the search for the metadata section from the end of the file.
+const metadataMaxSize = 128 * 1024
+
+// maxDepth bounds the nesting of maps and arrays in the data section.
+const maxDepth = 32
+
+// Metadata describes a database.
+type Metadata struct {
+	NodeCount                uint
+	RecordSize               uint
+	IPVersion                uint
+	DatabaseType             string
+	Languages                []string
+	BinaryFormatMajorVersion uint
+	BinaryFormatMinorVersion uint
+	BuildEpoch               uint64
+	Description              map[string]string
+}
+
+// Reader reads MaxMind DB (.mmdb) files. It is safe for concurrent use.
+type Reader struct {
+	Metadata Metadata
+
+	tree      []byte
+	data      []byte
+	nodeSize  uint
+	ipv4Start uint
+}
+
+// Open reads the database file at name into memory.
+func Open(name string) (*Reader, error) {
+	buf, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewReader(buf)
+}
+
+// NewReader creates a Reader from the contents of a database file. The
+// buffer must not be modified afterwards.
+func NewReader(buf []byte) (*Reader, error) {
+	start := max(0, len(buf)-metadataMaxSize)
+	pos := bytes.LastIndex(buf[start:], metadataMarker)
+	if pos < 0 {
+		return nil, fmt.Errorf("%w: metadata not found", ErrInvalidDatabase)
+	}
+	metaStart := start + pos + len(metadataMarker)
+
+	raw, _, err := decode(buf[metaStart:], 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	meta, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata is not a map", ErrInvalidDatabase)
+	}
+
+	r := &Reader{Metadata: Metadata{
+		NodeCount:                uint(asUint(meta["node_count"])),
+		RecordSize:               uint(asUint(meta["record_size"])),
+		IPVersion:                uint(asUint(meta["ip_version"])),
+		DatabaseType:             asString(meta["database_type"]),
+		BinaryFormatMajorVersion: uint(asUint(meta["binary_format_major_version"])),
+		BinaryFormatMinorVersion: uint(asUint(meta["binary_format_minor_version"])),
+		BuildEpoch:               asUint(meta["build_epoch"]),
+	}}
+	if langs, ok := meta["languages"].([]any); ok {
+		for _, l := range langs {
+			r.Metadata.Languages = append(r.Metadata.Languages, asString(l))
+		}
+	}
+	if desc, ok := meta["description"].(map[string]any); ok {
+		r.Metadata.Description = make(map[string]string, len(desc))
+		for k, v := range desc {
+			r.Metadata.Description[k] = asString(v)
+		}
+	}
+
+	switch r.Metadata.RecordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("%w: unsupported record size %d", ErrInvalidDatabase, r.Metadata.RecordSize)
+	}
+	if r.Metadata.IPVersion != 4 && r.Metadata.IPVersion != 6 {
+		return nil, fmt.Errorf("%w: unsupported IP version %d", ErrInvalidDatabase, r.Metadata.IPVersion)
+	}
+
+	r.nodeSize = r.Metadata.RecordSize / 4
+	treeSize := r.Metadata.NodeCount * r.nodeSize
+	if treeSize+16 > uint(start+pos) {
+		return nil, fmt.Errorf("%w: search tree exceeds file size", ErrInvalidDatabase)
+	}
+	r.tree = buf[:treeSize]
+	r.data = buf[treeSize+16 : start+pos]
+
+	if r.Metadata.IPVersion == 6 {
+		for i := 0; i < 96 && r.ipv4Start < r.Metadata.NodeCount; i++ {
+			r.ipv4Start = r.record(r.ipv4Start, 0)
+		}
+	}
+	return r, nil
+}
+
+// Lookup returns the record for addr, decoded into maps, slices, strings,
+// bools, float64, uint64, int64, *big.Int and []byte values. Returns nil if
+// the database has no record for addr.
+func (r *Reader) Lookup(addr netip.Addr) (any, error) {
+	if !addr.IsValid() {
+		return nil, nil
+	}
+
+	var ip []byte
+	node := uint(0)
+	if addr = addr.Unmap(); addr.Is4() {
+		b := addr.As4()
+		ip, node = b[:], r.ipv4Start
+	} else if r.Metadata.IPVersion == 4 {
+		return nil, ErrIPVersion
+	} else {
+		b := addr.As16()
+		ip = b[:]
+	}
+
+	nodeCount := r.Metadata.NodeCount
+	for i := 0; i < len(ip)*8 && node < nodeCount; i++ {
+		bit := uint(ip[i>>3]>>(7-uint(i&7))) & 1
+		node = r.record(node, bit)
+	}
+
+	switch {
+	case node == nodeCount:
+		return nil, nil
+	case node < nodeCount:
+		return nil, fmt.Errorf("%w: search tree too deep", ErrInvalidDatabase)
+	}
+
+	off := node - nodeCount - 16
+	if off >= uint(len(r.data)) {
+		return nil, fmt.Errorf("%w: data pointer out of range", ErrInvalidDatabase)
+	}
+	val, _, err := decode(r.data, off, 0)
+	return val, err
+}
+
+// record returns the left (bit 0) or right (bit 1) record of a node.
+func (r *Reader) record(node, bit uint) uint {
+	b := r.tree[node*r.nodeSize : (node+1)*r.nodeSize]
+	switch r.Metadata.RecordSize {
+	case 24:
+		b = b[bit*3:]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		return uint(binary.BigEndian.Uint32(b[bit*4:]))
+	}
+}
+
+// Data section field types.
+const (
+	typeExtended = iota
+	typePointer
+	typeString
+	typeDouble
+	typeBytes
+	typeUint16
+	typeUint32
+	typeMap
+	typeInt32
+	typeUint64
+	typeUint128
+	typeArray
+	typeContainer
+	typeEndMarker
+	typeBool
+	typeFloat
+)
+
+var errTruncated = fmt.Errorf("%w: unexpected end of data", ErrInvalidDatabase)
+
+// decode decodes the value at off and returns it with the offset of the
+// following value. Pointers are resolved relative to the start of buf.
+func decode(buf []byte, off uint, depth int) (any, uint, error) {
+	if depth > maxDepth {
+		return nil, 0, fmt.Errorf("%w: data nested too deeply", ErrInvalidDatabase)
+	}
+	if off >= uint(len(buf)) {
+		return nil, 0, errTruncated
+	}
+
+	ctrl := buf[off]
+	off++
+
+	typ := int(ctrl >> 5)
+	if typ == typePointer {
+		ptr, next, err := decodePointer(buf, ctrl, off)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ptr >= uint(len(buf)) || buf[ptr]>>5 == typePointer {
+			return nil, 0, fmt.Errorf("%w: invalid pointer", ErrInvalidDatabase)
+		}
+		val, _, err := decode(buf, ptr, depth+1)
+		return val, next, err
+	}
+
+	if typ == typeExtended {
+		if off >= uint(len(buf)) {
+			return nil, 0, errTruncated
+		}
+		typ = 7 + int(buf[off])
+		off++
+	}
+
+	size := uint(ctrl & 0x1f)
+	if size >= 29 {
+		n := size - 28
+		if off+n > uint(len(buf)) {
+			return nil, 0, errTruncated
+		}
+		v := uint(0)
+		for _, b := range buf[off : off+n] {
+			v = v<<8 | uint(b)
+		}
+		off += n
+		size = [...]uint{29, 285, 65821}[n-1] + v
+	}
+
+	switch typ {
+	case typeMap:
+		m := make(map[string]any, size)
+		for i := uint(0); i < size; i++ {
+			k, next, err := decode(buf, off, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("%w: map key is not a string", ErrInvalidDatabase)
+			}
+			v, next, err := decode(buf, next, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[key], off = v, next
+		}
+		return m, off, nil
+	case typeArray:
+		a := make([]any, 0, min(size, 1024))
+		for i := uint(0); i < size; i++ {
+			v, next, err := decode(buf, off, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			a, off = append(a, v), next
+		}
+		return a, off, nil
+	case typeBool:
+		if size > 1 {
+			return nil, 0, fmt.Errorf("%w: invalid boolean", ErrInvalidDatabase)
+		}
+		return size == 1, off, nil
+	}
+
+	if off+size > uint(len(buf)) {
+		return nil, 0, errTruncated
+	}
+	b := buf[off : off+size]
+	off += size
+
+	switch typ {
+	case typeString:
+		return string(b), off, nil
+	case typeBytes:
+		return append([]byte(nil), b...), off, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("%w: invalid double size", ErrInvalidDatabase)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), off, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("%w: invalid float size", ErrInvalidDatabase)
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), off, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > [...]uint{typeUint16: 2, typeUint32: 4, typeUint64: 8}[typ] {
+			return nil, 0, fmt.Errorf("%w: invalid integer size", ErrInvalidDatabase)
+		}
+		v := uint64(0)
+		for _, c := range b {
+			v = v<<8 | uint64(c)
+		}
+		return v, off, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("%w: invalid integer size", ErrInvalidDatabase)
+		}
+		v := uint32(0)
+		for _, c := range b {
+			v = v<<8 | uint32(c)
+		}
+		return int64(int32(v)), off, nil
+	case typeUint128:
+		if size > 16 {
+			return nil, 0, fmt.Errorf("%w: invalid integer size", ErrInvalidDatabase)
+		}
+		return new(big.Int).SetBytes(b), off, nil
+	}
+	return nil, 0, fmt.Errorf("%w: unsupported data type %d", ErrInvalidDatabase, typ)
+}
+
+func decodePointer(buf []byte, ctrl byte, off uint) (uint, uint, error) {
+	n := uint(ctrl>>3)&0x3 + 1
+	if off+n > uint(len(buf)) {
+		return 0, 0, errTruncated
+	}
+
+	v := uint(ctrl & 0x7)
+	if n == 4 {
+		v = 0
+	}
+	for _, b := range buf[off : off+n] {
+		v = v<<8 | uint(b)
+	}
+	return v + [...]uint{0, 2048, 526336, 0}[n-1], off + n, nil
+}
+
+func asUint(v any) uint64 {
+	switch n := v.(type) {
+	case uint64:
+		return n
+	case int64:
+		if n > 0 {
+			return uint64(n)
+		}
+	}
+	return 0
+}
+
+func asString(v any) string {
+	s, _ := v.(string)
+	return s
+}
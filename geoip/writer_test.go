@@ -0,0 +1,192 @@
+package geoip_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"net/netip"
+	"sort"
+)
+
+// mmdbWriter builds small MaxMind DB files for tests.
+type mmdbWriter struct {
+	ipVersion  int
+	recordSize int
+	nodes      [][2]*mmdbRecord
+	data       bytes.Buffer
+}
+
+type mmdbRecord struct {
+	node int // child node index, or -1 for data
+	data int // offset in the data section
+}
+
+func newMMDBWriter(ipVersion, recordSize int) *mmdbWriter {
+	return &mmdbWriter{ipVersion: ipVersion, recordSize: recordSize, nodes: make([][2]*mmdbRecord, 1)}
+}
+
+// mmdbPointer encodes a pointer to an offset in the data section.
+type mmdbPointer int
+
+// Insert assigns value to all addresses within prefix and returns the data
+// offset of value. IPv4 prefixes are inserted into the IPv4 subtree (::/96)
+// of IPv6 databases. Prefixes must not overlap.
+func (w *mmdbWriter) Insert(prefix string, value any) int {
+	p := netip.MustParsePrefix(prefix)
+
+	var ip []byte
+	bits := p.Bits()
+	if p.Addr().Is4() && w.ipVersion == 6 {
+		b := netip.AddrFrom4(p.Addr().As4()).As16()
+		b[10], b[11] = 0, 0
+		ip, bits = b[:], bits+96
+	} else {
+		ip = p.Addr().AsSlice()
+	}
+
+	off := w.data.Len()
+	encodeMMDB(&w.data, value)
+
+	node := 0
+	for i := 0; i < bits; i++ {
+		bit := ip[i/8] >> (7 - uint(i%8)) & 1
+		if i == bits-1 {
+			w.nodes[node][bit] = &mmdbRecord{node: -1, data: off}
+			break
+		}
+		if rec := w.nodes[node][bit]; rec != nil && rec.node >= 0 {
+			node = rec.node
+			continue
+		}
+		w.nodes = append(w.nodes, [2]*mmdbRecord{})
+		w.nodes[node][bit] = &mmdbRecord{node: len(w.nodes) - 1}
+		node = len(w.nodes) - 1
+	}
+	return off
+}
+
+// Bytes returns the encoded database.
+func (w *mmdbWriter) Bytes() []byte {
+	var buf bytes.Buffer
+
+	count := len(w.nodes)
+	value := func(rec *mmdbRecord) uint32 {
+		switch {
+		case rec == nil:
+			return uint32(count)
+		case rec.node >= 0:
+			return uint32(rec.node)
+		default:
+			return uint32(count + 16 + rec.data)
+		}
+	}
+	for _, node := range w.nodes {
+		l, r := value(node[0]), value(node[1])
+		switch w.recordSize {
+		case 24:
+			buf.Write([]byte{byte(l >> 16), byte(l >> 8), byte(l), byte(r >> 16), byte(r >> 8), byte(r)})
+		case 28:
+			buf.Write([]byte{byte(l >> 16), byte(l >> 8), byte(l), byte(l>>20)&0xF0 | byte(r>>24)&0x0F, byte(r >> 16), byte(r >> 8), byte(r)})
+		default:
+			buf.Write(binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(nil, l), r))
+		}
+	}
+
+	buf.Write(make([]byte, 16))
+	buf.Write(w.data.Bytes())
+	buf.WriteString("\xAB\xCD\xEFMaxMind.com")
+	encodeMMDB(&buf, map[string]any{
+		"node_count":                  uint32(count),
+		"record_size":                 uint16(w.recordSize),
+		"ip_version":                  uint16(w.ipVersion),
+		"database_type":               "Test-City",
+		"languages":                   []any{"en"},
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"build_epoch":                 uint64(1700000000),
+		"description":                 map[string]any{"en": "Test database"},
+	})
+	return buf.Bytes()
+}
+
+func encodeMMDB(buf *bytes.Buffer, v any) {
+	switch v := v.(type) {
+	case mmdbPointer:
+		switch p := int(v); {
+		case p < 2048:
+			buf.Write([]byte{1<<5 | byte(p>>8), byte(p)})
+		case p < 526336:
+			p -= 2048
+			buf.Write([]byte{1<<5 | 1<<3 | byte(p>>16), byte(p >> 8), byte(p)})
+		default:
+			p -= 526336
+			buf.Write([]byte{1<<5 | 2<<3 | byte(p>>24), byte(p >> 16), byte(p >> 8), byte(p)})
+		}
+	case string:
+		writeMMDBControl(buf, 2, len(v))
+		buf.WriteString(v)
+	case float64:
+		writeMMDBControl(buf, 3, 8)
+		buf.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(v)))
+	case uint16:
+		writeMMDBControl(buf, 5, 2)
+		buf.Write(binary.BigEndian.AppendUint16(nil, v))
+	case uint32:
+		writeMMDBControl(buf, 6, 4)
+		buf.Write(binary.BigEndian.AppendUint32(nil, v))
+	case uint64:
+		writeMMDBControl(buf, 9, 8)
+		buf.Write(binary.BigEndian.AppendUint64(nil, v))
+	case int32:
+		writeMMDBControl(buf, 8, 4)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(v)))
+	case bool:
+		n := 0
+		if v {
+			n = 1
+		}
+		writeMMDBControl(buf, 14, n)
+	case []any:
+		writeMMDBControl(buf, 11, len(v))
+		for _, e := range v {
+			encodeMMDB(buf, e)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		writeMMDBControl(buf, 7, len(v))
+		for _, k := range keys {
+			encodeMMDB(buf, k)
+			encodeMMDB(buf, v[k])
+		}
+	default:
+		panic("mmdb: unsupported type")
+	}
+}
+
+func writeMMDBControl(buf *bytes.Buffer, typ, size int) {
+	var ext []byte
+	switch {
+	case size < 29:
+	case size < 285:
+		ext, size = []byte{byte(size - 29)}, 29
+	case size < 65821:
+		n := size - 285
+		ext, size = []byte{byte(n >> 8), byte(n)}, 30
+	default:
+		n := size - 65821
+		ext, size = []byte{byte(n >> 16), byte(n >> 8), byte(n)}, 31
+	}
+
+	if typ > 7 {
+		buf.WriteByte(byte(size))
+		buf.WriteByte(byte(typ - 7))
+	} else {
+		buf.WriteByte(byte(typ<<5 | size))
+	}
+	buf.Write(ext)
+}
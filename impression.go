@@ -26,6 +26,7 @@ type Impression struct {
 	DisplayManager        string          `json:"displaymanager,omitempty"`    // Name of ad mediation partner, SDK technology, etc
 	DisplayManagerVersion string          `json:"displaymanagerver,omitempty"` // Version of the above
 	TagID                 string          `json:"tagid,omitempty"`             // IDentifier for specific ad placement or ad tag
+	GPID                  string          `json:"gpid,omitempty"`              // Global Placement ID, a stable identifier of the ad unit across exchanges. Passed as imp.ext.gpid before 2.6.
 	BidFloorCurrency      string          `json:"bidfloorcur,omitempty"`       // Currency of bid floor
 	Banner                *Banner         `json:"banner,omitempty"`            // -
 	Video                 *Video          `json:"video,omitempty"`             // -
@@ -70,3 +71,15 @@ func (imp *Impression) Validate() error {
 
 	return nil
 }
+
+func (imp *Impression) validateAll(errs *violations, path string) {
+	if imp.ID == "" {
+		errs.add(path, ErrInvalidImpNoID)
+	}
+	if count := imp.assetCount(); count > 1 {
+		errs.add(path, ErrInvalidImpMultiAssets)
+	}
+	if imp.Video != nil {
+		imp.Video.validateAll(errs, path+".video")
+	}
+}
@@ -0,0 +1,85 @@
+// Package clonetest provides helpers to verify the generated Clone methods.
+package clonetest
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Fill recursively populates all exported fields of the value v points to with
+// non-zero values. Slices are populated with two elements each.
+func Fill(v interface{}) {
+	fill(reflect.ValueOf(v).Elem())
+}
+
+func fill(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				fill(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(`{"x":1}`))
+			return
+		}
+		v.Set(reflect.MakeSlice(v.Type(), 2, 2))
+		for i := 0; i < v.Len(); i++ {
+			fill(v.Index(i))
+		}
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	}
+}
+
+// Aliases returns the paths of all pointers and slices which are shared
+// between a and b.
+func Aliases(a, b interface{}) []string {
+	var paths []string
+	aliases(reflect.ValueOf(a), reflect.ValueOf(b), "", &paths)
+	return paths
+}
+
+func aliases(a, b reflect.Value, path string, paths *[]string) {
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			*paths = append(*paths, path)
+			return
+		}
+		aliases(a.Elem(), b.Elem(), path, paths)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if a.Type().Field(i).IsExported() {
+				aliases(a.Field(i), b.Field(i), path+"."+a.Type().Field(i).Name, paths)
+			}
+		}
+	case reflect.Slice:
+		if a.Cap() == 0 || b.Cap() == 0 {
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			*paths = append(*paths, path)
+			return
+		}
+		for i := 0; i < a.Len() && i < b.Len(); i++ {
+			aliases(a.Index(i), b.Index(i), path+"["+strconv.Itoa(i)+"]", paths)
+		}
+	}
+}
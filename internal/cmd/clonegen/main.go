@@ -0,0 +1,215 @@
+// Command clonegen generates the deep Clone methods of the OpenRTB types.
+//
+// It is invoked through go generate from the module root and writes a
+// clone_gen.go file into each of the packages.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/tomlightning/openrtb/v3"
+	"github.com/tomlightning/openrtb/v3/native/request"
+	"github.com/tomlightning/openrtb/v3/native/response"
+)
+
+type target struct {
+	Dir   string
+	Roots []reflect.Type
+}
+
+var targets = []target{
+	{Dir: ".", Roots: []reflect.Type{
+		reflect.TypeOf(openrtb.BidRequest{}),
+		reflect.TypeOf(openrtb.BidResponse{}),
+		reflect.TypeOf(openrtb.ThirdParty{}),
+	}},
+	{Dir: "native/request", Roots: []reflect.Type{
+		reflect.TypeOf(request.Request{}),
+	}},
+	{Dir: "native/response", Roots: []reflect.Type{
+		reflect.TypeOf(response.Response{}),
+	}},
+}
+
+func main() {
+	for _, tg := range targets {
+		if err := generate(tg); err != nil {
+			log.Fatalln(err)
+		}
+	}
+}
+
+func generate(tg target) error {
+	g := &generator{pkgPath: tg.Roots[0].PkgPath(), types: make(map[string]reflect.Type)}
+	for _, t := range tg.Roots {
+		g.collect(t)
+	}
+
+	src, err := g.render()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(tg.Dir, "clone_gen.go"), src, 0o644)
+}
+
+type generator struct {
+	pkgPath string
+	types   map[string]reflect.Type
+	buf     bytes.Buffer
+}
+
+// collect registers t and all struct types of the same package reachable from it.
+func (g *generator) collect(t reflect.Type) {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		g.collect(t.Elem())
+	case reflect.Struct:
+		if t.PkgPath() != g.pkgPath {
+			return
+		}
+		if _, ok := g.types[t.Name()]; ok {
+			return
+		}
+		g.types[t.Name()] = t
+		for i := 0; i < t.NumField(); i++ {
+			g.collect(t.Field(i).Type)
+		}
+	}
+}
+
+// isFlat returns true if values of t can be copied by assignment.
+func isFlat(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return false
+	case reflect.Array:
+		return isFlat(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !isFlat(t.Field(i).Type) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (g *generator) render() ([]byte, error) {
+	pkg := g.types[sortedNames(g.types)[0]].PkgPath()
+	pkg = pkg[strings.LastIndexByte(pkg, '/')+1:]
+	if pkg == "v3" {
+		pkg = "openrtb"
+	}
+
+	g.printf("// Code generated by clonegen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", pkg)
+
+	for _, name := range sortedNames(g.types) {
+		if err := g.renderType(g.types[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	g.printf("func cloneSlice[E any](s []E) []E {\n")
+	g.printf("if s == nil {\nreturn nil\n}\n")
+	g.printf("return append(make([]E, 0, len(s)), s...)\n")
+	g.printf("}\n")
+
+	return format.Source(g.buf.Bytes())
+}
+
+func (g *generator) renderType(t reflect.Type) error {
+	name := t.Name()
+
+	g.printf("// Clone returns a deep copy of the %s.\n", name)
+	g.printf("func (x *%s) Clone() *%s {\n", name, name)
+	g.printf("if x == nil {\nreturn nil\n}\n")
+	g.printf("c := new(%s)\n", name)
+	g.printf("x.cloneInto(c)\n")
+	g.printf("return c\n")
+	g.printf("}\n\n")
+
+	g.printf("func (x *%s) cloneInto(c *%s) {\n", name, name)
+	g.printf("*c = *x\n")
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if isFlat(field.Type) {
+			continue
+		}
+		if err := g.renderField(field); err != nil {
+			return fmt.Errorf("%s.%s: %w", name, field.Name, err)
+		}
+	}
+	g.printf("}\n\n")
+	return nil
+}
+
+func (g *generator) renderField(field reflect.StructField) error {
+	name, t := field.Name, field.Type
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t.PkgPath() != g.pkgPath {
+			return fmt.Errorf("unsupported foreign struct %s", t)
+		}
+		g.printf("x.%s.cloneInto(&c.%s)\n", name, name)
+	case reflect.Ptr:
+		switch el := t.Elem(); {
+		case el.Kind() == reflect.Struct && el.PkgPath() == g.pkgPath:
+			g.printf("c.%s = x.%s.Clone()\n", name, name)
+		case isFlat(el):
+			g.printf("if x.%s != nil {\nv := *x.%s\nc.%s = &v\n}\n", name, name, name)
+		default:
+			return fmt.Errorf("unsupported pointer %s", t)
+		}
+	case reflect.Slice:
+		switch el := t.Elem(); {
+		case isFlat(el):
+			g.printf("c.%s = cloneSlice(x.%s)\n", name, name)
+		case el.Kind() == reflect.Struct && el.PkgPath() == g.pkgPath:
+			g.printf("if x.%s != nil {\n", name)
+			g.printf("c.%s = make(%s, len(x.%s))\n", name, g.typeName(t), name)
+			g.printf("for i := range x.%s {\nx.%s[i].cloneInto(&c.%s[i])\n}\n", name, name, name)
+			g.printf("}\n")
+		default:
+			return fmt.Errorf("unsupported slice %s", t)
+		}
+	default:
+		return fmt.Errorf("unsupported kind %s", t.Kind())
+	}
+	return nil
+}
+
+func (g *generator) typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Slice:
+		return "[]" + g.typeName(t.Elem())
+	case reflect.Ptr:
+		return "*" + g.typeName(t.Elem())
+	}
+	if t.PkgPath() == g.pkgPath {
+		return t.Name()
+	}
+	return t.String()
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func sortedNames(m map[string]reflect.Type) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
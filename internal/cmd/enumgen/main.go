@@ -0,0 +1,385 @@
+// Command enumgen generates the name and validation methods of the OpenRTB
+// enum types.
+//
+// It is invoked through go generate from the module root and writes an
+// enum_gen.go file into each of the packages. Names are derived from the
+// constants declared for each type, with the type prefix removed.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type enum struct {
+	Type     string
+	Prefixes []string // constant name prefixes, the longest match is removed; defaults to the type name
+	Unset    bool     // the zero constant only marks an unset value
+}
+
+type target struct {
+	Dir   string
+	Enums []enum
+}
+
+var targets = []target{
+	{Dir: ".", Enums: []enum{
+		{Type: "BannerType"},
+		{Type: "CreativeAttribute"},
+		{Type: "AdPosition"},
+		{Type: "ExpDir", Unset: true},
+		{Type: "APIFramework", Unset: true},
+		{Type: "VideoLinearity", Unset: true},
+		{Type: "Protocol", Unset: true},
+		{Type: "VideoPlacement", Unset: true},
+		{Type: "VideoPlayback", Unset: true},
+		{Type: "ProductionQuality"},
+		{Type: "CompanionType", Unset: true},
+		{Type: "ContentDelivery", Unset: true},
+		{Type: "FeedType", Unset: true},
+		{Type: "VolumeNorm"},
+		{Type: "ContentContext"},
+		{Type: "IQGRating", Unset: true},
+		{Type: "LocationType", Unset: true},
+		{Type: "DeviceType", Unset: true},
+		{Type: "ConnType"},
+		{Type: "IPLocation", Unset: true},
+		{Type: "NBR"},
+		{Type: "PodSequence", Prefixes: []string{"PodSeq"}},
+		{Type: "SlotPositionInPod", Prefixes: []string{"SlotPos"}},
+		{Type: "MarkupType", Prefixes: []string{"Markup"}, Unset: true},
+		{Type: "CategoryTaxonomy"},
+		{Type: "VideoPlcmt"},
+		{Type: "UASource"},
+	}},
+	{Dir: "native/request", Enums: []enum{
+		{Type: "LayoutID", Prefixes: []string{"Layout"}},
+		{Type: "AdUnitID", Prefixes: []string{"AdUnit"}},
+		{Type: "ContextTypeID", Prefixes: []string{"ContextType", "Context"}},
+		{Type: "PlacementTypeID", Prefixes: []string{"PlacementType"}},
+		{Type: "ImageTypeID", Prefixes: []string{"ImageType"}},
+		{Type: "DataTypeID", Prefixes: []string{"DataType"}},
+	}},
+}
+
+func main() {
+	for _, tg := range targets {
+		if err := generate(tg); err != nil {
+			log.Fatalln(err)
+		}
+	}
+}
+
+func generate(tg target) error {
+	pkg, err := parse(tg.Dir)
+	if err != nil {
+		return err
+	}
+
+	g := &generator{pkg: pkg}
+	src, err := g.render(tg.Enums)
+	if err != nil {
+		return fmt.Errorf("%s: %w", tg.Dir, err)
+	}
+	return os.WriteFile(filepath.Join(tg.Dir, "enum_gen.go"), src, 0o644)
+}
+
+// --------------------------------------------------------------------
+
+type constant struct {
+	Name  string
+	Value int64
+}
+
+type pkgInfo struct {
+	Name       string
+	Underlying map[string]string     // type name -> underlying integer type
+	Constants  map[string][]constant // type name -> declared constants
+	Errors     map[string]error      // type name -> unsupported declarations
+}
+
+// parse collects the enum declarations of the hand-written sources in dir.
+func parse(dir string) (*pkgInfo, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi fs.FileInfo) bool {
+		name := fi.Name()
+		return !strings.HasSuffix(name, "_test.go") && !strings.HasSuffix(name, "_gen.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("%s: expected a single package, found %d", dir, len(pkgs))
+	}
+
+	info := &pkgInfo{
+		Underlying: make(map[string]string),
+		Constants:  make(map[string][]constant),
+		Errors:     make(map[string]error),
+	}
+	for name, pkg := range pkgs {
+		info.Name = name
+		for _, file := range pkg.Files {
+			info.collect(file)
+		}
+	}
+	return info, nil
+}
+
+func (info *pkgInfo) collect(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if id, ok := s.Type.(*ast.Ident); ok {
+						info.Underlying[s.Name.Name] = id.Name
+					}
+				case *ast.ValueSpec:
+					if d.Tok != token.CONST || s.Type == nil || len(s.Values) != len(s.Names) {
+						continue
+					}
+					typ, ok := s.Type.(*ast.Ident)
+					if !ok {
+						continue
+					}
+					for i, name := range s.Names {
+						v, err := intValue(s.Values[i])
+						if err != nil {
+							info.Errors[typ.Name] = fmt.Errorf("%s: %w", name.Name, err)
+							continue
+						}
+						info.Constants[typ.Name] = append(info.Constants[typ.Name], constant{Name: name.Name, Value: v})
+					}
+				}
+			}
+		}
+	}
+}
+
+func intValue(expr ast.Expr) (int64, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind == token.INT {
+			return strconv.ParseInt(e.Value, 0, 64)
+		}
+	case *ast.UnaryExpr:
+		if e.Op == token.SUB {
+			v, err := intValue(e.X)
+			return -v, err
+		}
+	case *ast.ParenExpr:
+		return intValue(e.X)
+	}
+	return 0, fmt.Errorf("unsupported constant expression %T", expr)
+}
+
+// --------------------------------------------------------------------
+
+type value struct {
+	constant
+	Label string
+	Valid bool
+}
+
+type generator struct {
+	pkg *pkgInfo
+	buf bytes.Buffer
+}
+
+func (g *generator) render(enums []enum) ([]byte, error) {
+	sort.Slice(enums, func(i, j int) bool { return enums[i].Type < enums[j].Type })
+
+	g.printf("// Code generated by enumgen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", g.pkg.Name)
+	g.printf("import (\n\"fmt\"\n\"strconv\"\n\"strings\"\n)\n\n")
+
+	for _, e := range enums {
+		if err := g.renderEnum(e); err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Type, err)
+		}
+	}
+	g.renderHelpers()
+
+	return format.Source(g.buf.Bytes())
+}
+
+func (g *generator) values(e enum) ([]value, error) {
+	prefixes := e.Prefixes
+	if len(prefixes) == 0 {
+		prefixes = []string{e.Type}
+	}
+
+	if err := g.pkg.Errors[e.Type]; err != nil {
+		return nil, err
+	}
+
+	consts := g.pkg.Constants[e.Type]
+	if len(consts) == 0 {
+		return nil, fmt.Errorf("no constants declared")
+	}
+
+	values := make([]value, 0, len(consts))
+	seen := make(map[string]string, len(consts))
+	for _, c := range consts {
+		label := ""
+		for _, prefix := range prefixes {
+			if rest := strings.TrimPrefix(c.Name, prefix); rest != c.Name && (label == "" || len(rest) < len(label)) {
+				label = rest
+			}
+		}
+		if label == "" {
+			return nil, fmt.Errorf("constant %s does not match prefixes %v", c.Name, prefixes)
+		}
+		if prev, ok := seen[strings.ToLower(label)]; ok {
+			return nil, fmt.Errorf("constants %s and %s have the same name", prev, c.Name)
+		}
+		seen[strings.ToLower(label)] = c.Name
+
+		values = append(values, value{constant: c, Label: label, Valid: !e.Unset || c.Value != 0})
+	}
+	sort.SliceStable(values, func(i, j int) bool { return values[i].Value < values[j].Value })
+
+	for i := 1; i < len(values); i++ {
+		if values[i].Value == values[i-1].Value {
+			return nil, fmt.Errorf("constants %s and %s have the same value", values[i-1].Name, values[i].Name)
+		}
+	}
+	return values, nil
+}
+
+func (g *generator) renderEnum(e enum) error {
+	name := e.Type
+	switch g.pkg.Underlying[name] {
+	case "int", "int8", "int16", "int32", "int64":
+	default:
+		return fmt.Errorf("unsupported underlying type %q", g.pkg.Underlying[name])
+	}
+
+	values, err := g.values(e)
+	if err != nil {
+		return err
+	}
+
+	var valid []value
+	for _, v := range values {
+		if v.Valid {
+			valid = append(valid, v)
+		}
+	}
+	names := unexported(name) + "Names"
+
+	g.printf("var %s = map[%s]string{\n", names, name)
+	for _, v := range values {
+		g.printf("%s: %q,\n", v.Name, v.Label)
+	}
+	g.printf("}\n\n")
+
+	g.printf("// String returns the name of the %s.\n", name)
+	g.printf("func (x %s) String() string {\nreturn formatEnum(%s, %q, x)\n}\n\n", name, names, name)
+
+	g.printf("// IsValid returns true if x is defined by the standard.\n")
+	g.printf("func (x %s) IsValid() bool {\n", name)
+	if contiguous(valid) {
+		g.printf("return x >= %s && x <= %s\n", valid[0].Name, valid[len(valid)-1].Name)
+	} else {
+		g.printf("switch x {\ncase ")
+		for i, v := range valid {
+			if i > 0 {
+				g.printf(", ")
+			}
+			g.printf("%s", v.Name)
+		}
+		g.printf(":\nreturn true\n}\nreturn false\n")
+	}
+	g.printf("}\n\n")
+
+	g.printf("// %sValues returns all valid %s values.\n", name, name)
+	g.printf("func %sValues() []%s {\nreturn []%s{\n", name, name, name)
+	for _, v := range valid {
+		g.printf("%s,\n", v.Name)
+	}
+	g.printf("}\n}\n\n")
+
+	g.printf("// Text returns the name of x, or its number if it has none.\n// Unlike String, the result can be parsed with Parse%s.\n", name)
+	g.printf("func (x %s) Text() string {\nreturn enumText(%s, x, %t)\n}\n\n", name, names, e.Unset)
+
+	g.printf("// Parse%s returns the %s for a case-insensitive name or a numeric value.\n", name, name)
+	g.printf("func Parse%s(s string) (%s, error) {\nreturn parseEnum(%s, %q, s, %t)\n}\n\n", name, name, names, name, e.Unset)
+
+	g.printf("// Set parses s into x, see Parse%s. It implements flag.Value.\n", name)
+	g.printf("func (x *%s) Set(s string) (err error) {\n*x, err = Parse%s(s)\nreturn err\n}\n\n", name, name)
+	return nil
+}
+
+// unexported lower-cases the leading word or acronym of name.
+func unexported(name string) string {
+	n := 0
+	for n < len(name) && name[n] >= 'A' && name[n] <= 'Z' {
+		n++
+	}
+	if n > 1 && n < len(name) {
+		n-- // keep the first letter of the next word
+	}
+	return strings.ToLower(name[:n]) + name[n:]
+}
+
+func contiguous(values []value) bool {
+	for i := 1; i < len(values); i++ {
+		if values[i].Value != values[i-1].Value+1 {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *generator) renderHelpers() {
+	g.printf(`type enumInt interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+func formatEnum[T enumInt](names map[T]string, typ string, x T) string {
+	if s, ok := names[x]; ok {
+		return s
+	}
+	return typ + "(" + strconv.FormatInt(int64(x), 10) + ")"
+}
+
+// enumText returns the name of x, or its number if it has none. If zeroUnset
+// is set, the zero value only marks an unset field and has no name.
+func enumText[T enumInt](names map[T]string, x T, zeroUnset bool) string {
+	if s, ok := names[x]; ok && (x != 0 || !zeroUnset) {
+		return s
+	}
+	return strconv.FormatInt(int64(x), 10)
+}
+
+func parseEnum[T enumInt](names map[T]string, typ, s string, zeroUnset bool) (T, error) {
+	for x, name := range names {
+		if strings.EqualFold(name, s) && (x != 0 || !zeroUnset) {
+			return x, nil
+		}
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && int64(T(n)) == n {
+		return T(n), nil
+	}
+	return 0, fmt.Errorf("%%w: %%s %%q", ErrEnumName, typ, s)
+}
+`)
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
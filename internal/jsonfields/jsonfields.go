@@ -0,0 +1,77 @@
+// Package jsonfields resolves the JSON-visible fields of struct types.
+package jsonfields
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var cache sync.Map // map[reflect.Type]*entry
+
+type entry struct {
+	fields map[string]reflect.StructField
+	folded map[string]reflect.StructField // by lower-cased name
+}
+
+func load(t reflect.Type) *entry {
+	if v, ok := cache.Load(t); ok {
+		return v.(*entry)
+	}
+
+	e := &entry{fields: make(map[string]reflect.StructField, t.NumField())}
+	collect(t, nil, e.fields)
+
+	e.folded = make(map[string]reflect.StructField, len(e.fields))
+	for name, field := range e.fields {
+		e.folded[strings.ToLower(name)] = field
+	}
+
+	v, _ := cache.LoadOrStore(t, e)
+	return v.(*entry)
+}
+
+// Of returns the JSON-visible fields of a struct type by name, including
+// those promoted from embedded structs. The result must not be modified.
+func Of(t reflect.Type) map[string]reflect.StructField {
+	return load(t).fields
+}
+
+// Lookup returns the field of a struct type which a JSON key decodes into.
+// Like encoding/json, keys without an exact match are matched
+// case-insensitively.
+func Lookup(t reflect.Type, key string) (reflect.StructField, bool) {
+	e := load(t)
+	if field, ok := e.fields[key]; ok {
+		return field, true
+	}
+	field, ok := e.folded[strings.ToLower(key)]
+	return field, ok
+}
+
+func collect(t reflect.Type, index []int, fields map[string]reflect.StructField) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		field.Index = append(append([]int(nil), index...), i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			collect(field.Type, field.Index, fields)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := fields[name]; !ok || len(field.Index) < len(fields[name].Index) {
+			fields[name] = field
+		}
+	}
+}
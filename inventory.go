@@ -10,6 +10,7 @@ type Inventory struct {
 	Categories        []ContentCategory `json:"cat,omitempty"`           // Array of IAB content categories
 	SectionCategories []ContentCategory `json:"sectioncat,omitempty"`    // Array of IAB content categories for subsection
 	PageCategories    []ContentCategory `json:"pagecat,omitempty"`       // Array of IAB content categories for page
+	CategoryTaxonomy  CategoryTaxonomy  `json:"cattax,omitempty"`        // The taxonomy in use for cat, sectioncat and pagecat, default: IAB Content Taxonomy 1.0.
 	PrivacyPolicy     *int              `json:"privacypolicy,omitempty"` // Default: 1 ("1": has a privacy policy)
 	Publisher         *Publisher        `json:"publisher,omitempty"`     // Details about the Publisher
 	Content           *Content          `json:"content,omitempty"`       // Details about the Content
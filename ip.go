@@ -0,0 +1,206 @@
+package openrtb
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// Validation errors for device IP addresses.
+var (
+	ErrInvalidDeviceIP   = errors.New("openrtb: invalid device IP")
+	ErrInvalidDeviceIPv6 = errors.New("openrtb: invalid device IPv6")
+	ErrNonPublicDeviceIP = errors.New("openrtb: device IP is not publicly routable")
+)
+
+// Default prefix lengths retained by TruncateIP.
+const (
+	DefaultIPv4Prefix = 24
+	DefaultIPv6Prefix = 56
+)
+
+// IPClass classifies IP addresses by routability.
+type IPClass int8
+
+// IPClass options.
+const (
+	IPInvalid     IPClass = iota // Not a valid address
+	IPPublic                     // Publicly routable address
+	IPPrivate                    // Private network (RFC 1918, RFC 4193)
+	IPLoopback                   // Loopback address
+	IPLinkLocal                  // Link-local unicast address
+	IPMulticast                  // Multicast or broadcast address
+	IPUnspecified                // Unspecified address, e.g. 0.0.0.0
+	IPReserved                   // Reserved, shared, documentation or benchmarking ranges
+)
+
+var ipClassNames = [...]string{
+	IPInvalid:     "invalid",
+	IPPublic:      "public",
+	IPPrivate:     "private",
+	IPLoopback:    "loopback",
+	IPLinkLocal:   "link-local",
+	IPMulticast:   "multicast",
+	IPUnspecified: "unspecified",
+	IPReserved:    "reserved",
+}
+
+// String returns the name of the class.
+func (c IPClass) String() string {
+	if c >= 0 && int(c) < len(ipClassNames) {
+		return ipClassNames[c]
+	}
+	return fmt.Sprintf("IPClass(%d)", c)
+}
+
+// reservedPrefixes lists special-purpose ranges not covered by netip.Addr's predicates.
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),   // shared address space (CGNAT)
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // TEST-NET-1
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // TEST-NET-2
+	netip.MustParsePrefix("203.0.113.0/24"),  // TEST-NET-3
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved for future use
+	netip.MustParsePrefix("100::/64"),        // discard-only
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("fec0::/10"),       // deprecated site-local
+}
+
+// ClassifyIP classifies addr. IPv4-mapped IPv6 addresses are classified as
+// their IPv4 equivalent.
+func ClassifyIP(addr netip.Addr) IPClass {
+	if !addr.IsValid() {
+		return IPInvalid
+	}
+
+	addr = addr.Unmap()
+	switch {
+	case addr.IsUnspecified():
+		return IPUnspecified
+	case addr.IsLoopback():
+		return IPLoopback
+	case addr.IsPrivate():
+		return IPPrivate
+	case addr.IsLinkLocalUnicast():
+		return IPLinkLocal
+	case addr.IsMulticast(), addr == netip.AddrFrom4([4]byte{255, 255, 255, 255}):
+		return IPMulticast
+	}
+	for _, p := range reservedPrefixes {
+		if p.Contains(addr) {
+			return IPReserved
+		}
+	}
+	return IPPublic
+}
+
+// TruncateIP zeroes all but the first v4Bits (IPv4) or v6Bits (IPv6) bits of
+// addr. Non-positive prefix lengths default to DefaultIPv4Prefix and
+// DefaultIPv6Prefix. IPv4-mapped IPv6 addresses are truncated as IPv4 but
+// remain mapped.
+func TruncateIP(addr netip.Addr, v4Bits, v6Bits int) netip.Addr {
+	if !addr.IsValid() {
+		return addr
+	}
+	if v4Bits <= 0 {
+		v4Bits = DefaultIPv4Prefix
+	}
+	if v6Bits <= 0 {
+		v6Bits = DefaultIPv6Prefix
+	}
+
+	bits := min(v6Bits, 128)
+	if addr.Is4() {
+		bits = min(v4Bits, 32)
+	} else if addr.Is4In6() {
+		bits = 96 + min(v4Bits, 32)
+	}
+
+	prefix, _ := addr.Prefix(bits)
+	return prefix.Addr()
+}
+
+// IPAddrs parses the IP and IPv6 fields. Empty fields are returned as zero
+// addresses.
+func (d *Device) IPAddrs() (v4, v6 netip.Addr, err error) {
+	if s := strings.TrimSpace(d.IP); s != "" {
+		if v4, err = netip.ParseAddr(s); err != nil {
+			return v4, v6, fmt.Errorf("%w: %q", ErrInvalidDeviceIP, d.IP)
+		}
+	}
+	if s := strings.TrimSpace(d.IPv6); s != "" {
+		if v6, err = netip.ParseAddr(s); err != nil {
+			return v4, v6, fmt.Errorf("%w: %q", ErrInvalidDeviceIPv6, d.IPv6)
+		}
+	}
+	return v4, v6, nil
+}
+
+// NormalizeIP rewrites the IP and IPv6 fields to their canonical forms.
+// IPv4 and IPv4-mapped IPv6 addresses in the IPv6 field are moved to the IP
+// field and IPv6 addresses in the IP field are moved to the IPv6 field,
+// unless the target field is already populated. The device remains
+// unchanged if either field cannot be parsed.
+func (d *Device) NormalizeIP() error {
+	v4, v6, err := d.IPAddrs()
+	if err != nil {
+		return err
+	}
+
+	if v4.Is4In6() {
+		v4 = v4.Unmap()
+	}
+	if v6.Is4() || v6.Is4In6() {
+		if !v4.IsValid() {
+			v4, v6 = v6.Unmap(), netip.Addr{}
+		}
+	}
+	if v4.Is6() && !v6.IsValid() {
+		v4, v6 = netip.Addr{}, v4
+	}
+
+	d.IP, d.IPv6 = addrString(v4), addrString(v6)
+	return nil
+}
+
+// TruncateIP anonymizes the IP and IPv6 fields using TruncateIP.
+func (d *Device) TruncateIP(v4Bits, v6Bits int) error {
+	v4, v6, err := d.IPAddrs()
+	if err != nil {
+		return err
+	}
+
+	d.IP = addrString(TruncateIP(v4, v4Bits, v6Bits))
+	d.IPv6 = addrString(TruncateIP(v6, v4Bits, v6Bits))
+	return nil
+}
+
+// ValidateIP returns an error if the IP or IPv6 fields cannot be parsed or
+// contain addresses which are not publicly routable. Requests failing this
+// check should be rejected with NBRInvalidRequest.
+func (d *Device) ValidateIP() error {
+	v4, v6, err := d.IPAddrs()
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range []netip.Addr{v4, v6} {
+		if !addr.IsValid() {
+			continue
+		}
+		if class := ClassifyIP(addr); class != IPPublic {
+			return fmt.Errorf("%w: %s is %s", ErrNonPublicDeviceIP, addr, class)
+		}
+	}
+	return nil
+}
+
+func addrString(addr netip.Addr) string {
+	if !addr.IsValid() {
+		return ""
+	}
+	return addr.String()
+}
@@ -0,0 +1,132 @@
+package openrtb_test
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestClassifyIP(t *testing.T) {
+	for s, exp := range map[string]IPClass{
+		"123.145.167.189":          IPPublic,
+		"2a00:1450:4001:82b::200e": IPPublic,
+		"::ffff:123.145.167.189":   IPPublic,
+		"10.1.2.3":                 IPPrivate,
+		"172.16.0.1":               IPPrivate,
+		"192.168.1.1":              IPPrivate,
+		"::ffff:192.168.1.1":       IPPrivate,
+		"fd12:3456:789a::1":        IPPrivate,
+		"127.0.0.1":                IPLoopback,
+		"::1":                      IPLoopback,
+		"169.254.10.1":             IPLinkLocal,
+		"fe80::1":                  IPLinkLocal,
+		"224.0.0.1":                IPMulticast,
+		"255.255.255.255":          IPMulticast,
+		"ff02::1":                  IPMulticast,
+		"0.0.0.0":                  IPUnspecified,
+		"::":                       IPUnspecified,
+		"100.64.1.1":               IPReserved,
+		"192.0.2.1":                IPReserved,
+		"198.18.0.1":               IPReserved,
+		"203.0.113.7":              IPReserved,
+		"240.1.2.3":                IPReserved,
+		"2001:db8::1":              IPReserved,
+	} {
+		if got := ClassifyIP(netip.MustParseAddr(s)); exp != got {
+			t.Errorf("%s: expected %v, got %v", s, exp, got)
+		}
+	}
+
+	if exp, got := IPInvalid, ClassifyIP(netip.Addr{}); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestTruncateIP(t *testing.T) {
+	for _, tc := range []struct {
+		addr           string
+		v4Bits, v6Bits int
+		exp            string
+	}{
+		{"123.145.167.189", 0, 0, "123.145.167.0"},
+		{"123.145.167.189", 16, 0, "123.145.0.0"},
+		{"123.145.167.189", 64, 0, "123.145.167.189"},
+		{"2a00:1450:4001:82b::200e", 0, 0, "2a00:1450:4001:800::"},
+		{"2a00:1450:4001:82b::200e", 0, 48, "2a00:1450:4001::"},
+		{"::ffff:123.145.167.189", 0, 0, "::ffff:123.145.167.0"},
+	} {
+		if got := TruncateIP(netip.MustParseAddr(tc.addr), tc.v4Bits, tc.v6Bits).String(); tc.exp != got {
+			t.Errorf("%s/%d/%d: expected %v, got %v", tc.addr, tc.v4Bits, tc.v6Bits, tc.exp, got)
+		}
+	}
+}
+
+func TestDevice_NormalizeIP(t *testing.T) {
+	for _, tc := range []struct {
+		ip, ipv6       string
+		expIP, expIPv6 string
+	}{
+		{"123.145.167.189", "", "123.145.167.189", ""},
+		{" 123.145.167.189 ", "", "123.145.167.189", ""},
+		{"", "::ffff:123.145.167.189", "123.145.167.189", ""},
+		{"", "123.145.167.189", "123.145.167.189", ""},
+		{"::ffff:123.145.167.189", "", "123.145.167.189", ""},
+		{"2A00:1450:4001:082b::200e", "", "", "2a00:1450:4001:82b::200e"},
+		{"123.145.167.189", "::ffff:1.2.3.4", "123.145.167.189", "::ffff:1.2.3.4"},
+		{"2a00:1450:4001:82b::1", "2a00:1450:4001:82b::2", "2a00:1450:4001:82b::1", "2a00:1450:4001:82b::2"},
+	} {
+		subject := &Device{IP: tc.ip, IPv6: tc.ipv6}
+		if err := subject.NormalizeIP(); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if subject.IP != tc.expIP || subject.IPv6 != tc.expIPv6 {
+			t.Errorf("expected %q/%q, got %q/%q", tc.expIP, tc.expIPv6, subject.IP, subject.IPv6)
+		}
+	}
+
+	subject := &Device{IP: "123.145.167", IPv6: "::ffff:1.2.3.4"}
+	if err := subject.NormalizeIP(); !errors.Is(err, ErrInvalidDeviceIP) {
+		t.Errorf("expected %v, got %v", ErrInvalidDeviceIP, err)
+	}
+	if exp, got := "::ffff:1.2.3.4", subject.IPv6; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	subject = &Device{IPv6: "unknown"}
+	if err := subject.NormalizeIP(); !errors.Is(err, ErrInvalidDeviceIPv6) {
+		t.Errorf("expected %v, got %v", ErrInvalidDeviceIPv6, err)
+	}
+}
+
+func TestDevice_TruncateIP(t *testing.T) {
+	subject := &Device{IP: "123.145.167.189", IPv6: "2a00:1450:4001:82b::200e"}
+	if err := subject.TruncateIP(0, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := "123.145.167.0", subject.IP; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := "2a00:1450:4001:800::", subject.IPv6; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestDevice_ValidateIP(t *testing.T) {
+	for _, tc := range []struct {
+		ip, ipv6 string
+		exp      error
+	}{
+		{"", "", nil},
+		{"123.145.167.189", "2a00:1450:4001:82b::200e", nil},
+		{"192.168.0.1", "", ErrNonPublicDeviceIP},
+		{"123.145.167.189", "::1", ErrNonPublicDeviceIP},
+		{"localhost", "", ErrInvalidDeviceIP},
+	} {
+		subject := &Device{IP: tc.ip, IPv6: tc.ipv6}
+		if got := subject.ValidateIP(); !errors.Is(got, tc.exp) {
+			t.Errorf("%q/%q: expected %v, got %v", tc.ip, tc.ipv6, tc.exp, got)
+		}
+	}
+}
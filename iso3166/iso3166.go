@@ -0,0 +1,127 @@
+// Package iso3166 provides ISO 3166-1 country and ISO 3166-2 subdivision
+// codes, as well as the mapping of FIPS 10-4 region codes to ISO 3166-2.
+//
+// The tables are embedded as CSV files, the subdivisions are derived from the
+// iso-codes project. FIPS 10-4 codes are currently available for AU, BR, CA,
+// DE, GB, MX and US.
+package iso3166
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"strings"
+)
+
+var (
+	//go:embed countries.csv
+	countriesCSV []byte
+
+	//go:embed subdivisions.csv
+	subdivisionsCSV []byte
+)
+
+// Country is an ISO 3166-1 country.
+type Country struct {
+	Alpha2 string // ISO 3166-1 alpha-2 code, e.g. "US"
+	Alpha3 string // ISO 3166-1 alpha-3 code, e.g. "USA"
+	Name   string // English short name
+}
+
+// Subdivision is an ISO 3166-2 country subdivision.
+type Subdivision struct {
+	Code    string // ISO 3166-2 code, e.g. "US-CA"
+	Country string // ISO 3166-1 alpha-2 code of the country, e.g. "US"
+	Name    string // Name of the subdivision
+	FIPS    string // FIPS 10-4 region code, e.g. "US06", if known
+}
+
+// Local returns the subdivision code without the country prefix, e.g. "CA".
+func (s Subdivision) Local() string {
+	return strings.TrimPrefix(s.Code, s.Country+"-")
+}
+
+var (
+	countries    = make(map[string]*Country)     // by alpha-2 and alpha-3
+	subdivisions = make(map[string]*Subdivision) // by code and "<country>-<upper name>"
+	fips         = make(map[string]*Subdivision) // by FIPS code
+	fipsCountry  = make(map[string]bool)         // FIPS country prefixes with known regions
+	hasSubdivs   = make(map[string]bool)         // alpha-2 codes with known subdivisions
+)
+
+func init() {
+	for _, rec := range readCSV(countriesCSV) {
+		c := &Country{Alpha2: rec[0], Alpha3: rec[1], Name: rec[2]}
+		countries[c.Alpha2] = c
+		countries[c.Alpha3] = c
+	}
+
+	for _, rec := range readCSV(subdivisionsCSV) {
+		country, _, _ := strings.Cut(rec[0], "-")
+		s := &Subdivision{Code: rec[0], Country: country, Name: rec[1], FIPS: rec[2]}
+		subdivisions[s.Code] = s
+		if name := country + "-" + strings.ToUpper(s.Name); subdivisions[name] == nil {
+			subdivisions[name] = s // the first of equally named subdivisions wins
+		}
+		hasSubdivs[country] = true
+		if s.FIPS != "" {
+			fips[s.FIPS] = s
+			fipsCountry[s.FIPS[:2]] = true
+		}
+	}
+}
+
+func readCSV(data []byte) [][]string {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		panic("iso3166: " + err.Error())
+	}
+	return records[1:] // skip header
+}
+
+// LookupCountry returns the country identified by an alpha-2 or alpha-3
+// code. The lookup is case-insensitive.
+func LookupCountry(code string) (Country, bool) {
+	if c, ok := countries[strings.ToUpper(strings.TrimSpace(code))]; ok {
+		return *c, true
+	}
+	return Country{}, false
+}
+
+// HasSubdivisions returns true if the country, given as alpha-2 code, has
+// subdivisions. Some territories, e.g. "VA", have none.
+func HasSubdivisions(country string) bool {
+	return hasSubdivs[country]
+}
+
+// LookupSubdivision returns the subdivision of a country, given as alpha-2
+// code, identified by its ISO 3166-2 code with or without country prefix
+// (e.g. "US-CA" or "CA") or its name (e.g. "California"). The lookup is
+// case-insensitive.
+func LookupSubdivision(country, code string) (Subdivision, bool) {
+	country = strings.ToUpper(country)
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	if s, ok := subdivisions[code]; ok && (country == "" || s.Country == country) {
+		return *s, true
+	}
+	if s, ok := subdivisions[country+"-"+code]; ok {
+		return *s, true
+	}
+	return Subdivision{}, false
+}
+
+// LookupFIPS returns the subdivision identified by a FIPS 10-4 region code,
+// e.g. "US06".
+func LookupFIPS(code string) (Subdivision, bool) {
+	if s, ok := fips[strings.ToUpper(strings.TrimSpace(code))]; ok {
+		return *s, true
+	}
+	return Subdivision{}, false
+}
+
+// HasFIPS returns true if the regions of a FIPS 10-4 country code, e.g. "GM"
+// for Germany, are known.
+func HasFIPS(country string) bool {
+	return fipsCountry[strings.ToUpper(country)]
+}
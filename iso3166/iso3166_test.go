@@ -0,0 +1,92 @@
+package iso3166_test
+
+import (
+	"testing"
+
+	. "github.com/tomlightning/openrtb/v3/iso3166"
+)
+
+func TestLookupCountry(t *testing.T) {
+	for code, exp := range map[string]Country{
+		"US":  {Alpha2: "US", Alpha3: "USA", Name: "United States of America"},
+		"usa": {Alpha2: "US", Alpha3: "USA", Name: "United States of America"},
+		"GB":  {Alpha2: "GB", Alpha3: "GBR", Name: "United Kingdom"},
+		"deu": {Alpha2: "DE", Alpha3: "DEU", Name: "Germany"},
+		"CD":  {Alpha2: "CD", Alpha3: "COD", Name: "Congo, Democratic Republic of the"},
+	} {
+		got, ok := LookupCountry(code)
+		if !ok || exp != got {
+			t.Errorf("%s: expected %+v, got %+v", code, exp, got)
+		}
+	}
+
+	for _, code := range []string{"", "UK", "XX", "EUR"} {
+		if got, ok := LookupCountry(code); ok {
+			t.Errorf("%s: expected no country, got %+v", code, got)
+		}
+	}
+}
+
+func TestLookupSubdivision(t *testing.T) {
+	exp := Subdivision{Code: "US-CA", Country: "US", Name: "California", FIPS: "US06"}
+	for _, tc := range []struct{ country, code string }{
+		{"US", "CA"},
+		{"us", "ca"},
+		{"US", "US-CA"},
+		{"", "US-CA"},
+		{"US", "California"},
+	} {
+		got, ok := LookupSubdivision(tc.country, tc.code)
+		if !ok || exp != got {
+			t.Errorf("%s/%s: expected %+v, got %+v", tc.country, tc.code, exp, got)
+		}
+	}
+	for _, tc := range []struct{ country, code, exp string }{
+		{"FR", "IDF", "FR-IDF"},
+		{"FR", "Île-de-France", "FR-IDF"},
+		{"JP", "JP-13", "JP-13"},
+		{"ES", "Catalunya", "ES-CT"},
+	} {
+		if got, _ := LookupSubdivision(tc.country, tc.code); tc.exp != got.Code {
+			t.Errorf("%s/%s: expected %v, got %+v", tc.country, tc.code, tc.exp, got)
+		}
+	}
+	if !HasSubdivisions("FR") || HasSubdivisions("VA") {
+		t.Error("expected FR to have subdivisions and VA to have none")
+	}
+	if exp, got := "CA", exp.Local(); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	for _, tc := range []struct{ country, code string }{
+		{"", "CA"},
+		{"CA", "US-CA"},
+		{"US", "XX"},
+		{"FR", "XX"},
+	} {
+		if got, ok := LookupSubdivision(tc.country, tc.code); ok {
+			t.Errorf("%s/%s: expected no subdivision, got %+v", tc.country, tc.code, got)
+		}
+	}
+}
+
+func TestLookupFIPS(t *testing.T) {
+	for code, exp := range map[string]string{
+		"US06": "US-CA",
+		"CA08": "CA-ON",
+		"GM02": "DE-BY",
+		"AS02": "AU-NSW",
+	} {
+		got, ok := LookupFIPS(code)
+		if !ok || exp != got.Code {
+			t.Errorf("%s: expected %v, got %+v", code, exp, got)
+		}
+	}
+
+	if got, ok := LookupFIPS("US03"); ok {
+		t.Errorf("expected no subdivision, got %+v", got)
+	}
+	if !HasFIPS("GM") || HasFIPS("DE") {
+		t.Error("expected FIPS country GM to be known and DE to be unknown")
+	}
+}
@@ -0,0 +1,107 @@
+package openrtb
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// MarshalLossless encodes v like json.Marshal, but restores fields that were
+// captured as unknown by Decode. This allows to decode a document, modify it
+// and re-encode it without losing any non-standard fields outside of ext.
+//
+// Unknown fields are restored by their JSON path, fields of objects that no
+// longer exist (e.g. because an impression was removed) are dropped and
+// fields that are now set by v take precedence. Array elements with an "id"
+// attribute, such as impressions or bids, are matched by their ID, so fields
+// follow their element when the array is reordered. Other array elements are
+// addressed by their position.
+func MarshalLossless(v interface{}, unknown []UnknownField) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(unknown) == 0 {
+		return data, err
+	}
+
+	tree, err := decodeTree(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range unknown {
+		obj, ok := lookupPath(tree, field.Parent, field.ParentIDs).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, exists := obj[field.Key]; !exists {
+			obj[field.Key] = field.Value
+		}
+	}
+	return json.Marshal(tree)
+}
+
+// lookupPath returns the node at a path like "imp[0].video" or nil if not
+// found. Array elements are matched by the IDs given for them, if any.
+func lookupPath(node interface{}, path string, ids []string) interface{} {
+	for depth := 0; path != "" && node != nil; {
+		var key string
+		if path[0] == '[' {
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil
+			}
+
+			arr, ok := node.([]interface{})
+			if !ok {
+				return nil
+			}
+
+			var id string
+			if depth < len(ids) {
+				id = ids[depth]
+			}
+			depth++
+
+			if id != "" {
+				node = findByID(arr, id)
+			} else if pos, err := strconv.Atoi(path[1:end]); err == nil && pos >= 0 && pos < len(arr) {
+				node = arr[pos]
+			} else {
+				return nil
+			}
+			path = strings.TrimPrefix(path[end+1:], ".")
+			continue
+		}
+
+		key, path = path, ""
+		if n := strings.IndexAny(key, ".["); n > -1 {
+			key, path = key[:n], strings.TrimPrefix(key[n:], ".")
+		}
+
+		obj, ok := node.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		node = obj[key]
+	}
+	return node
+}
+
+// findByID returns the element of arr with the given "id" attribute.
+func findByID(arr []interface{}, id string) interface{} {
+	for _, el := range arr {
+		if elementID(el) == id {
+			return el
+		}
+	}
+	return nil
+}
+
+// elementID returns the "id" attribute of an array element, if any.
+func elementID(el interface{}) string {
+	if obj, ok := el.(map[string]interface{}); ok {
+		id, _ := obj["id"].(string)
+		return id
+	}
+	return ""
+}
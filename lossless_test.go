@@ -0,0 +1,152 @@
+package openrtb_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestMarshalLossless(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "breq.exp.json"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var subject *BidRequest
+	report, err := Decode(data, &subject, DecodeLenient)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := 2, len(report.Unknown); exp != got {
+		t.Fatalf("expected %v, got %v", exp, got)
+	}
+
+	subject.TimeMax = 250
+	subject.Site.Content.Keywords = "keyword1,keyword2"
+
+	data, err = MarshalLossless(subject, report.Unknown)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var got struct {
+		TimeMax int `json:"tmax"`
+		Site    struct {
+			SiteCat []string `json:"sitecat"`
+			Content struct {
+				Keyword  []string `json:"keyword"`
+				Keywords string   `json:"keywords"`
+			} `json:"content"`
+		} `json:"site"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp := 250; exp != got.TimeMax {
+		t.Errorf("expected %v, got %v", exp, got.TimeMax)
+	}
+	if exp := "keyword1,keyword2"; exp != got.Site.Content.Keywords {
+		t.Errorf("expected %v, got %v", exp, got.Site.Content.Keywords)
+	}
+	if exp := 3; exp != len(got.Site.Content.Keyword) {
+		t.Errorf("expected %v, got %v", exp, got.Site.Content.Keyword)
+	}
+	if exp := 2; exp != len(got.Site.SiteCat) {
+		t.Errorf("expected %v, got %v", exp, got.Site.SiteCat)
+	}
+}
+
+func TestMarshalLossless_nested(t *testing.T) {
+	data := []byte(`{"id":"1","imp":[{"id":"1","x":1},{"id":"2","video":{"mimes":["video/mp4"],"y":{"a":[1,2]}}}],"z":"top"}`)
+
+	var subject *BidRequest
+	report, err := Decode(data, &subject, DecodeLenient)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	subject.Impressions = subject.Impressions[1:]
+	data, err = MarshalLossless(subject, report.Unknown)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp := "top"; exp != got["z"] {
+		t.Errorf("expected %v, got %v", exp, got["z"])
+	}
+
+	imp := got["imp"].([]interface{})[0].(map[string]interface{})
+	if _, ok := imp["x"]; ok {
+		t.Errorf("expected x of imp 1 to be dropped, got %v", imp)
+	}
+	if _, ok := imp["video"].(map[string]interface{})["y"]; !ok {
+		t.Errorf("expected video.y to be restored, got %v", imp)
+	}
+}
+
+func TestMarshalLossless_reordered(t *testing.T) {
+	data := []byte(`{"id":"1","imp":[{"id":"1","x":1},{"id":"2","x":2}]}`)
+
+	var subject *BidRequest
+	report, err := Decode(data, &subject, DecodeLenient)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	subject.Impressions[0], subject.Impressions[1] = subject.Impressions[1], subject.Impressions[0]
+	data, err = MarshalLossless(subject, report.Unknown)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var got struct {
+		Imp []struct {
+			ID string `json:"id"`
+			X  int    `json:"x"`
+		} `json:"imp"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, imp := range got.Imp {
+		if exp := imp.ID; exp != strconv.Itoa(imp.X) {
+			t.Errorf("expected x %v, got %v", exp, imp.X)
+		}
+	}
+}
+
+func TestMarshalLossless_legacyKeys(t *testing.T) {
+	for _, tc := range []struct {
+		input   string
+		subject interface{}
+		exp     string
+	}{
+		{`{"regionFIPS104":"US06"}`, new(Geo), `{"regionfips104":"US06"}`},
+		{`{"hration":9}`, new(Format), `{"hratio":9}`},
+	} {
+		report, err := Decode([]byte(tc.input), tc.subject, DecodeStrict)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if exp, got := 0, len(report.Unknown); exp != got {
+			t.Errorf("expected %v, got %v (%v)", exp, got, report.Unknown)
+		}
+
+		data, err := MarshalLossless(tc.subject, report.Unknown)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if exp, got := tc.exp, string(data); exp != got {
+			t.Errorf("expected %v, got %v", exp, got)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package openrtb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomlightning/openrtb/v3/internal/jsonfields"
+)
+
+// ErrInvalidMask is returned when a mask cannot be parsed.
+var ErrInvalidMask = errors.New("openrtb: invalid mask")
+
+// MaskMode defines how the paths of a Mask are interpreted.
+type MaskMode string
+
+// MaskMode options.
+const (
+	MaskAllow MaskMode = "allow" // Keep only the listed paths
+	MaskDeny  MaskMode = "deny"  // Remove the listed paths
+)
+
+// Mask is a field mask which projects a BidRequest to a subset of its fields,
+// e.g. to strip data a bidder is not entitled to receive.
+//
+// Paths use the JSON attribute names separated by dots. A "*" matches any
+// attribute or any array element, a number matches a single array element.
+// Array elements must always be addressed explicitly, e.g. "imp.*.pmp".
+//
+// Masks can be loaded from JSON configuration:
+//
+//	{"mode": "deny", "paths": ["user.data", "device.ifa", "device.geo.lat", "device.geo.lon"]}
+type Mask struct {
+	Mode  MaskMode `json:"mode"`
+	Paths []string `json:"paths"`
+
+	root *maskNode
+}
+
+// NewMask compiles a mask. Paths are validated against the BidRequest schema.
+func NewMask(mode MaskMode, paths ...string) (*Mask, error) {
+	m := &Mask{Mode: mode, Paths: paths}
+	if err := m.compile(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LoadMask reads and compiles a mask from a JSON configuration.
+func LoadMask(r io.Reader) (*Mask, error) {
+	m := new(Mask)
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *Mask) UnmarshalJSON(data []byte) error {
+	type jsonMask Mask
+	if err := json.Unmarshal(data, (*jsonMask)(m)); err != nil {
+		return err
+	}
+	return m.compile()
+}
+
+// Apply returns a projected copy of req. The original request is not modified.
+func (m *Mask) Apply(req *BidRequest) *BidRequest {
+	if req == nil {
+		return nil
+	}
+
+	c := req.Clone()
+	m.root.apply(reflect.ValueOf(c).Elem(), m.Mode == MaskAllow)
+	return c
+}
+
+func (m *Mask) compile() error {
+	if m.Mode != MaskAllow && m.Mode != MaskDeny {
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidMask, m.Mode)
+	}
+
+	m.root = new(maskNode)
+	for _, path := range m.Paths {
+		if path == "" {
+			return fmt.Errorf("%w: empty path", ErrInvalidMask)
+		}
+
+		segs := strings.Split(path, ".")
+		if err := validateMaskPath(reflect.TypeOf(BidRequest{}), segs); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidMask, path, err)
+		}
+		m.root.insert(segs)
+	}
+	return nil
+}
+
+func validateMaskPath(t reflect.Type, segs []string) error {
+	for len(segs) != 0 {
+		switch t.Kind() {
+		case reflect.Ptr:
+			t = t.Elem()
+			continue
+		case reflect.Slice:
+			if t.Elem().Kind() == reflect.Uint8 {
+				return fmt.Errorf("cannot address %q within raw JSON", segs[0])
+			}
+			if segs[0] != "*" && !isArrayIndex(segs[0]) {
+				return fmt.Errorf("%q is not an array element, use \"*\" or an index", segs[0])
+			}
+			t, segs = t.Elem(), segs[1:]
+			continue
+		case reflect.Struct:
+		default:
+			return fmt.Errorf("%q is not an object", segs[0])
+		}
+
+		if segs[0] == "*" {
+			return validateWildcard(t, segs[1:])
+		}
+
+		field, ok := jsonfields.Of(t)[segs[0]]
+		if !ok {
+			return fmt.Errorf("unknown field %q", segs[0])
+		}
+		t, segs = field.Type, segs[1:]
+	}
+	return nil
+}
+
+// validateWildcard checks that segs, which follow a wildcard on struct t,
+// address a member of at least one of its fields.
+func validateWildcard(t reflect.Type, segs []string) error {
+	if len(segs) == 0 {
+		return nil
+	}
+	for _, field := range jsonfields.Of(t) {
+		if validateMaskPath(field.Type, segs) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q does not match any field", strings.Join(segs, "."))
+}
+
+func isArrayIndex(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 32)
+	return err == nil
+}
+
+// --------------------------------------------------------------------
+
+type maskNode struct {
+	children map[string]*maskNode
+	terminal bool
+}
+
+func (n *maskNode) insert(segs []string) {
+	for _, seg := range segs {
+		child, ok := n.children[seg]
+		if !ok {
+			if n.children == nil {
+				n.children = make(map[string]*maskNode)
+			}
+			child = new(maskNode)
+			n.children[seg] = child
+		}
+		n = child
+	}
+	n.terminal = true
+}
+
+// match returns the child nodes matching name.
+func (n *maskNode) match(name string) (nodes []*maskNode, terminal bool) {
+	for _, key := range [2]string{name, "*"} {
+		if child, ok := n.children[key]; ok {
+			nodes = append(nodes, child)
+			terminal = terminal || child.terminal
+		}
+	}
+	return
+}
+
+// apply applies the mask rooted at n to v. In allow mode, all fields not
+// covered by the mask are cleared, otherwise all fields covered are.
+func (n *maskNode) apply(v reflect.Value, allow bool) {
+	n.applyAll([]*maskNode{n}, v, allow)
+}
+
+func (n *maskNode) applyAll(nodes []*maskNode, v reflect.Value, allow bool) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			n.applyAll(nodes, v.Elem(), allow)
+		}
+	case reflect.Struct:
+		for name, field := range jsonfields.Of(v.Type()) {
+			var (
+				matched  []*maskNode
+				terminal bool
+			)
+			for _, node := range nodes {
+				m, t := node.match(name)
+				matched, terminal = append(matched, m...), terminal || t
+			}
+
+			fv := v.FieldByIndex(field.Index)
+			switch {
+			case terminal:
+				if !allow {
+					fv.SetZero()
+				}
+			case len(matched) != 0:
+				n.applyAll(matched, fv, allow)
+			case allow:
+				fv.SetZero()
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+
+		kept := 0
+		for i := 0; i < v.Len(); i++ {
+			var (
+				matched  []*maskNode
+				terminal bool
+			)
+			for _, node := range nodes {
+				m, t := node.match(strconv.Itoa(i))
+				matched, terminal = append(matched, m...), terminal || t
+			}
+
+			if terminal && !allow {
+				continue
+			}
+			if !terminal {
+				if len(matched) == 0 {
+					if allow {
+						continue
+					}
+				} else {
+					n.applyAll(matched, v.Index(i), allow)
+				}
+			}
+			v.Index(kept).Set(v.Index(i))
+			kept++
+		}
+		if kept == 0 {
+			v.SetZero()
+		} else {
+			v.SetLen(kept)
+		}
+	default:
+		if allow {
+			v.SetZero()
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package openrtb_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestLoadMask(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "mask.deny.json"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer f.Close()
+
+	subject, err := LoadMask(f)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var req *BidRequest
+	if err := fixture("breq.video", &req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	req.Device.IFA = "AA000DFE74168477C70D291f574D344790E0BB11"
+	req.Device.Geo = &Geo{Latitude: 35.01, Longitude: -115.12, Country: "USA"}
+
+	got := subject.Apply(req)
+	if got.User.Data != nil {
+		t.Errorf("expected no user data, got %+v", got.User.Data)
+	}
+	if exp := req.User.BuyerUID; exp != got.User.BuyerUID {
+		t.Errorf("expected %v, got %v", exp, got.User.BuyerUID)
+	}
+	if exp := (&Geo{Country: "USA"}); !reflect.DeepEqual(exp, got.Device.Geo) {
+		t.Errorf("expected %+v, got %+v", exp, got.Device.Geo)
+	}
+	if exp := ""; exp != got.Device.IFA {
+		t.Errorf("expected %v, got %v", exp, got.Device.IFA)
+	}
+	for _, imp := range got.Impressions {
+		if imp.PMP != nil {
+			t.Errorf("expected no PMP, got %+v", imp.PMP)
+		}
+		if imp.Video == nil {
+			t.Errorf("expected video to be retained")
+		}
+	}
+
+	if req.User.Data == nil || req.Device.IFA == "" || req.Impressions[0].PMP == nil {
+		t.Errorf("expected original to remain unchanged")
+	}
+}
+
+func TestMask_allow(t *testing.T) {
+	subject, err := NewMask(MaskAllow, "id", "at", "imp.*.id", "imp.*.video.w", "imp.0.video.h", "site", "device.geo.country")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var req *BidRequest
+	if err := fixture("breq.video", &req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	req.Device.Geo = &Geo{Latitude: 35.01, Country: "USA"}
+
+	got := subject.Apply(req)
+	exp := &BidRequest{
+		ID:          req.ID,
+		AuctionType: req.AuctionType,
+		Impressions: []Impression{
+			{ID: "1", Video: &Video{Width: 640, Height: 480}},
+			{ID: "2", Video: &Video{Width: 640}},
+			{ID: "3", Video: &Video{Width: 640}},
+		},
+		Site:   req.Site,
+		Device: &Device{Geo: &Geo{Country: "USA"}},
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+}
+
+func TestMask_dropElements(t *testing.T) {
+	subject, err := NewMask(MaskDeny, "imp.1", "bcat.*")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	req := &BidRequest{
+		ID:                "1",
+		Impressions:       []Impression{{ID: "1"}, {ID: "2"}, {ID: "3"}},
+		BlockedCategories: []ContentCategory{ContentCategoryAutomotive},
+	}
+	got := subject.Apply(req)
+	if exp := []Impression{{ID: "1"}, {ID: "3"}}; !reflect.DeepEqual(exp, got.Impressions) {
+		t.Errorf("expected %+v, got %+v", exp, got.Impressions)
+	}
+	if got.BlockedCategories != nil {
+		t.Errorf("expected no bcat, got %v", got.BlockedCategories)
+	}
+}
+
+func TestMask_invalid(t *testing.T) {
+	for _, data := range []string{
+		`{"mode":"keep","paths":["id"]}`,
+		`{"mode":"deny","paths":["device.foo"]}`,
+		`{"mode":"deny","paths":["imp.ext.bidder"]}`,
+		`{"mode":"deny","paths":["id.x"]}`,
+		`{"mode":"deny","paths":[""]}`,
+		`{"mode":"deny","paths":["imp.pmp"]}`,
+		`{"mode":"deny","paths":["device.*.nonexistent"]}`,
+		`{"mode":"deny","paths":["imp.*.*.foo"]}`,
+	} {
+		var m Mask
+		if err := json.Unmarshal([]byte(data), &m); !errors.Is(err, ErrInvalidMask) {
+			t.Errorf("expected %v, got %v for %s", ErrInvalidMask, err, data)
+		}
+	}
+
+	if _, err := NewMask(MaskDeny, "device.*.lat", "imp.*.*.w"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package matcher
+
+import "math/bits"
+
+// bitset is a set of line item slots.
+type bitset []uint64
+
+func words(n int) int { return (n + 63) / 64 }
+
+func (b *bitset) set(i uint32) {
+	w := int(i / 64)
+	if w >= len(*b) {
+		*b = append(*b, make(bitset, w-len(*b)+1)...)
+	}
+	(*b)[w] |= 1 << (i % 64)
+}
+
+func (b bitset) clear(i uint32) {
+	if w := int(i / 64); w < len(b) {
+		b[w] &^= 1 << (i % 64)
+	}
+}
+
+func (b bitset) empty() bool {
+	for _, w := range b {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// or adds the members of src to b. b must be at least as long as src.
+func (b bitset) or(src bitset) {
+	for i, w := range src {
+		b[i] |= w
+	}
+}
+
+// and removes all members of b not in src.
+func (b bitset) and(src bitset) {
+	n := min(len(b), len(src))
+	for i := 0; i < n; i++ {
+		b[i] &= src[i]
+	}
+	for i := n; i < len(b); i++ {
+		b[i] = 0
+	}
+}
+
+// each calls fn for each member of b.
+func (b bitset) each(fn func(uint32)) {
+	for i, w := range b {
+		for w != 0 {
+			fn(uint32(i*64 + bits.TrailingZeros64(w)))
+			w &= w - 1
+		}
+	}
+}
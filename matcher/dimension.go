@@ -0,0 +1,83 @@
+package matcher
+
+import (
+	"cmp"
+	"slices"
+)
+
+// dimension is an inverted index of line item slots by the values of one
+// targeting condition. Line items without a condition are kept in any.
+type dimension[K cmp.Ordered] struct {
+	any      bitset
+	postings map[K]bitset
+	sorted   []K // posting keys in ascending order, if ranged
+	ranged   bool
+}
+
+func newDimension[K cmp.Ordered](ranged bool) *dimension[K] {
+	return &dimension[K]{postings: make(map[K]bitset), ranged: ranged}
+}
+
+func (d *dimension[K]) add(slot uint32, keys []K) {
+	if len(keys) == 0 {
+		d.any.set(slot)
+		return
+	}
+
+	for _, k := range keys {
+		p, ok := d.postings[k]
+		if !ok && d.ranged {
+			i, _ := slices.BinarySearch(d.sorted, k)
+			d.sorted = slices.Insert(d.sorted, i, k)
+		}
+		p.set(slot)
+		d.postings[k] = p
+	}
+}
+
+func (d *dimension[K]) remove(slot uint32, keys []K) {
+	if len(keys) == 0 {
+		d.any.clear(slot)
+		return
+	}
+
+	for _, k := range keys {
+		p, ok := d.postings[k]
+		if !ok {
+			continue
+		}
+		if p.clear(slot); !p.empty() {
+			continue
+		}
+		delete(d.postings, k)
+		if d.ranged {
+			if i, found := slices.BinarySearch(d.sorted, k); found {
+				d.sorted = slices.Delete(d.sorted, i, i+1)
+			}
+		}
+	}
+}
+
+// collect adds the slots matching any of keys to dst. Line items without
+// condition are included if withAny is set.
+func (d *dimension[K]) collect(dst bitset, keys []K, withAny bool) {
+	if withAny {
+		dst.or(d.any)
+	}
+	for _, k := range keys {
+		if p, ok := d.postings[k]; ok {
+			dst.or(p)
+		}
+	}
+}
+
+// collectRange adds the slots with keys in [lo, hi] to dst, including line
+// items without condition.
+func (d *dimension[K]) collectRange(dst bitset, lo, hi K) {
+	dst.or(d.any)
+
+	i, _ := slices.BinarySearch(d.sorted, lo)
+	for ; i < len(d.sorted) && d.sorted[i] <= hi; i++ {
+		dst.or(d.postings[d.sorted[i]])
+	}
+}
@@ -0,0 +1,434 @@
+// Package matcher finds the campaign line items eligible for the impressions
+// of a bid request.
+//
+// Line items declare conditions on the impression (media type, size, ad
+// duration, deals) and the request (country, device type, content
+// categories). The Index keeps a posting list of line items per condition
+// value, so matching costs a few bitset operations per condition rather than
+// an evaluation of every line item. Remaining conditions can be expressed as
+// targeting expressions, which are evaluated for indexed candidates only.
+package matcher
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/tomlightning/openrtb/v3"
+	"github.com/tomlightning/openrtb/v3/iso3166"
+	"github.com/tomlightning/openrtb/v3/targeting"
+)
+
+// ErrNoID is returned when adding a line item without ID.
+var ErrNoID = errors.New("matcher: line item has no ID")
+
+// Size is a creative size in pixels.
+type Size struct {
+	W, H int
+}
+
+func (s Size) key() int64 { return int64(s.W)<<32 | int64(uint32(s.H)) }
+
+// LineItem is a campaign line item. Empty conditions match any value. Line
+// items must not be modified after they have been added to an Index.
+type LineItem struct {
+	ID string
+
+	MediaTypes  []openrtb.MarkupType      // Media types of the creative, matched against Banner, Video, Audio and Native
+	Sizes       []Size                    // Creative sizes, matched against banner sizes and formats as well as the video player size
+	Duration    int                       // Creative duration in seconds, matched against video and audio min/max durations
+	Deals       []string                  // Deal IDs; line items with deals are only eligible for impressions offering one of them
+	Countries   []string                  // Countries as ISO 3166-1 alpha-2 or alpha-3 codes, matched against the device or user geo
+	DeviceTypes []openrtb.DeviceType      // Device types; DeviceTypeMobile includes phones and tablets
+	Categories  []openrtb.ContentCategory // Content categories of the site, app or content; a tier 1 category such as "IAB19" includes its subcategories
+	Filter      *targeting.Expr           // Additional condition on the request, evaluated for candidates only
+}
+
+// Result is the set of line items matching an impression.
+type Result struct {
+	ImpID     string
+	LineItems []*LineItem
+}
+
+// Index is an inverted index of line items. It is safe for concurrent use,
+// line items may be added and removed while matching.
+type Index struct {
+	mu      sync.RWMutex
+	items   []*LineItem // by slot, nil if free
+	slots   map[string]uint32
+	free    []uint32
+	active  bitset
+	scratch sync.Pool
+
+	media       *dimension[openrtb.MarkupType]
+	sizes       *dimension[int64]
+	durations   *dimension[int]
+	deals       *dimension[string]
+	countries   *dimension[string]
+	deviceTypes *dimension[openrtb.DeviceType]
+	categories  *dimension[openrtb.ContentCategory]
+}
+
+// NewIndex creates an empty Index.
+func NewIndex() *Index {
+	return &Index{
+		slots:       make(map[string]uint32),
+		media:       newDimension[openrtb.MarkupType](false),
+		sizes:       newDimension[int64](false),
+		durations:   newDimension[int](true),
+		deals:       newDimension[string](false),
+		countries:   newDimension[string](false),
+		deviceTypes: newDimension[openrtb.DeviceType](false),
+		categories:  newDimension[openrtb.ContentCategory](false),
+	}
+}
+
+// Len returns the number of line items.
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	return len(ix.slots)
+}
+
+// Get returns the line item with the given ID.
+func (ix *Index) Get(id string) *LineItem {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	if slot, ok := ix.slots[id]; ok {
+		return ix.items[slot]
+	}
+	return nil
+}
+
+// Upsert adds a line item, replacing any line item with the same ID.
+func (ix *Index) Upsert(item *LineItem) error {
+	if item.ID == "" {
+		return ErrNoID
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	if slot, ok := ix.slots[item.ID]; ok {
+		ix.unindex(slot, ix.items[slot])
+		ix.items[slot] = item
+		ix.index(slot, item)
+		return nil
+	}
+
+	var slot uint32
+	if n := len(ix.free); n != 0 {
+		slot, ix.free = ix.free[n-1], ix.free[:n-1]
+		ix.items[slot] = item
+	} else {
+		slot = uint32(len(ix.items))
+		ix.items = append(ix.items, item)
+	}
+	ix.slots[item.ID] = slot
+	ix.index(slot, item)
+	return nil
+}
+
+// Remove removes the line item with the given ID. Returns false if no such
+// line item exists.
+func (ix *Index) Remove(id string) bool {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	slot, ok := ix.slots[id]
+	if !ok {
+		return false
+	}
+
+	ix.unindex(slot, ix.items[slot])
+	ix.items[slot] = nil
+	ix.free = append(ix.free, slot)
+	delete(ix.slots, id)
+	return true
+}
+
+func (ix *Index) index(slot uint32, item *LineItem) {
+	ix.active.set(slot)
+	ix.media.add(slot, item.MediaTypes)
+	ix.sizes.add(slot, sizeKeys(item.Sizes))
+	ix.durations.add(slot, durationKeys(item))
+	ix.deals.add(slot, item.Deals)
+	ix.countries.add(slot, countryKeys(item.Countries))
+	ix.deviceTypes.add(slot, item.DeviceTypes)
+	ix.categories.add(slot, item.Categories)
+}
+
+func (ix *Index) unindex(slot uint32, item *LineItem) {
+	ix.active.clear(slot)
+	ix.media.remove(slot, item.MediaTypes)
+	ix.sizes.remove(slot, sizeKeys(item.Sizes))
+	ix.durations.remove(slot, durationKeys(item))
+	ix.deals.remove(slot, item.Deals)
+	ix.countries.remove(slot, countryKeys(item.Countries))
+	ix.deviceTypes.remove(slot, item.DeviceTypes)
+	ix.categories.remove(slot, item.Categories)
+}
+
+func durationKeys(item *LineItem) []int {
+	if item.Duration <= 0 {
+		return nil
+	}
+	return []int{item.Duration}
+}
+
+func sizeKeys(sizes []Size) []int64 {
+	if len(sizes) == 0 {
+		return nil
+	}
+
+	keys := make([]int64, 0, len(sizes))
+	for _, sz := range sizes {
+		keys = append(keys, sz.key())
+	}
+	return keys
+}
+
+func countryKeys(countries []string) []string {
+	if len(countries) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(countries))
+	for _, c := range countries {
+		if country, ok := iso3166.LookupCountry(c); ok {
+			keys = append(keys, country.Alpha3)
+		} else {
+			keys = append(keys, strings.ToUpper(c))
+		}
+	}
+	return keys
+}
+
+type scratch struct {
+	req, imp, dim bitset
+	sizes         []int64
+	deals         []string
+	media         []openrtb.MarkupType
+}
+
+func (ix *Index) getScratch(n int) *scratch {
+	s, _ := ix.scratch.Get().(*scratch)
+	if s == nil {
+		s = new(scratch)
+	}
+	for _, b := range []*bitset{&s.req, &s.imp, &s.dim} {
+		if cap(*b) < n {
+			*b = make(bitset, n)
+		}
+		*b = (*b)[:n]
+	}
+	return s
+}
+
+// Match returns the line items matching each impression of req, in the order
+// of req.Impressions.
+func (ix *Index) Match(req *openrtb.BidRequest) []Result {
+	results := make([]Result, len(req.Impressions))
+	for i := range req.Impressions {
+		results[i].ImpID = req.Impressions[i].ID
+	}
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	n := words(len(ix.items))
+	s := ix.getScratch(n)
+	defer ix.scratch.Put(s)
+
+	// request level conditions
+	copy(s.req, ix.active)
+	clear(s.req[len(ix.active):])
+	restrict(s.req, s.dim, ix.countries, requestCountry(req))
+	restrict(s.req, s.dim, ix.deviceTypes, requestDeviceType(req))
+	restrict(s.req, s.dim, ix.categories, requestCategories(req))
+	if s.req.empty() {
+		return results
+	}
+
+	var filtered map[uint32]bool
+	for i := range req.Impressions {
+		imp := &req.Impressions[i]
+		copy(s.imp, s.req)
+
+		s.media = impMediaTypes(s.media[:0], imp)
+		restrict(s.imp, s.dim, ix.media, s.media)
+
+		s.sizes = impSizes(s.sizes[:0], imp)
+		restrict(s.imp, s.dim, ix.sizes, s.sizes)
+
+		if lo, hi, ok := impDurations(imp); ok {
+			clear(s.dim)
+			ix.durations.collectRange(s.dim, lo, hi)
+			s.imp.and(s.dim)
+		} else {
+			s.imp.and(ix.durations.any)
+		}
+
+		s.deals = s.deals[:0]
+		private := false
+		if pmp := imp.PMP; pmp != nil {
+			for _, deal := range pmp.Deals {
+				s.deals = append(s.deals, deal.ID)
+			}
+			private = pmp.Private == 1
+		}
+		clear(s.dim)
+		ix.deals.collect(s.dim, s.deals, !private)
+		s.imp.and(s.dim)
+
+		s.imp.each(func(slot uint32) {
+			item := ix.items[slot]
+			if item.Filter != nil {
+				ok, seen := filtered[slot]
+				if !seen {
+					if filtered == nil {
+						filtered = make(map[uint32]bool)
+					}
+					ok = item.Filter.Eval(req)
+					filtered[slot] = ok
+				}
+				if !ok {
+					return
+				}
+			}
+			results[i].LineItems = append(results[i].LineItems, item)
+		})
+	}
+	return results
+}
+
+// restrict removes the line items from dst which neither are without
+// condition on d nor match one of keys.
+func restrict[K cmp.Ordered](dst, tmp bitset, d *dimension[K], keys []K) {
+	clear(tmp)
+	d.collect(tmp, keys, true)
+	dst.and(tmp)
+}
+
+func requestCountry(req *openrtb.BidRequest) []string {
+	for _, geo := range []*openrtb.Geo{deviceGeo(req), userGeo(req)} {
+		if geo == nil || geo.Country == "" {
+			continue
+		}
+		if c, ok := iso3166.LookupCountry(geo.Country); ok {
+			return []string{c.Alpha3}
+		}
+		return []string{strings.ToUpper(geo.Country)}
+	}
+	return nil
+}
+
+func deviceGeo(req *openrtb.BidRequest) *openrtb.Geo {
+	if req.Device != nil {
+		return req.Device.Geo
+	}
+	return nil
+}
+
+func userGeo(req *openrtb.BidRequest) *openrtb.Geo {
+	if req.User != nil {
+		return req.User.Geo
+	}
+	return nil
+}
+
+func requestDeviceType(req *openrtb.BidRequest) []openrtb.DeviceType {
+	if req.Device == nil || req.Device.DeviceType == openrtb.DeviceTypeUnknown {
+		return nil
+	}
+
+	// mobile/tablet includes phones and tablets and vice versa
+	switch dt := req.Device.DeviceType; dt {
+	case openrtb.DeviceTypePhone, openrtb.DeviceTypeTablet:
+		return []openrtb.DeviceType{dt, openrtb.DeviceTypeMobile}
+	default:
+		return []openrtb.DeviceType{dt}
+	}
+}
+
+func requestCategories(req *openrtb.BidRequest) []openrtb.ContentCategory {
+	var inv *openrtb.Inventory
+	switch {
+	case req.Site != nil:
+		inv = &req.Site.Inventory
+	case req.App != nil:
+		inv = &req.App.Inventory
+	default:
+		return nil
+	}
+
+	var cats []openrtb.ContentCategory
+	add := func(src []openrtb.ContentCategory) {
+		for _, c := range src {
+			cats = append(cats, c)
+			if tier1, _, ok := strings.Cut(string(c), "-"); ok {
+				cats = append(cats, openrtb.ContentCategory(tier1))
+			}
+		}
+	}
+	add(inv.Categories)
+	add(inv.SectionCategories)
+	add(inv.PageCategories)
+	if inv.Content != nil {
+		add(inv.Content.Categories)
+	}
+	return cats
+}
+
+func impMediaTypes(dst []openrtb.MarkupType, imp *openrtb.Impression) []openrtb.MarkupType {
+	if imp.Banner != nil {
+		dst = append(dst, openrtb.MarkupBanner)
+	}
+	if imp.Video != nil {
+		dst = append(dst, openrtb.MarkupVideo)
+	}
+	if imp.Audio != nil {
+		dst = append(dst, openrtb.MarkupAudio)
+	}
+	if imp.Native != nil {
+		dst = append(dst, openrtb.MarkupNative)
+	}
+	return dst
+}
+
+func impSizes(dst []int64, imp *openrtb.Impression) []int64 {
+	if b := imp.Banner; b != nil {
+		if b.Width != 0 && b.Height != 0 {
+			dst = append(dst, Size{W: b.Width, H: b.Height}.key())
+		}
+		for _, f := range b.Formats {
+			if f.Width != 0 && f.Height != 0 {
+				dst = append(dst, Size{W: f.Width, H: f.Height}.key())
+			}
+		}
+	}
+	if v := imp.Video; v != nil && v.Width != 0 && v.Height != 0 {
+		dst = append(dst, Size{W: v.Width, H: v.Height}.key())
+	}
+	return dst
+}
+
+// impDurations returns the range of ad durations accepted by imp.
+func impDurations(imp *openrtb.Impression) (lo, hi int, ok bool) {
+	switch {
+	case imp.Video != nil:
+		lo, hi, ok = imp.Video.MinDuration, imp.Video.MaxDuration, true
+	case imp.Audio != nil:
+		lo, hi, ok = imp.Audio.MinDuration, imp.Audio.MaxDuration, true
+	default:
+		return 0, 0, false
+	}
+	if hi <= 0 {
+		hi = math.MaxInt
+	}
+	return lo, hi, ok
+}
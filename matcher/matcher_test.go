@@ -0,0 +1,223 @@
+package matcher_test
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/tomlightning/openrtb/v3"
+	"github.com/tomlightning/openrtb/v3/targeting"
+
+	. "github.com/tomlightning/openrtb/v3/matcher"
+)
+
+func fixture(t testing.TB, fname string) *openrtb.BidRequest {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("..", "testdata", fname+".json"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var req *openrtb.BidRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return req
+}
+
+func matchedIDs(results []Result) map[string][]string {
+	ids := make(map[string][]string, len(results))
+	for _, r := range results {
+		var matched []string
+		for _, item := range r.LineItems {
+			matched = append(matched, item.ID)
+		}
+		slices.Sort(matched)
+		ids[r.ImpID] = matched
+	}
+	return ids
+}
+
+func seedIndex(t testing.TB) *Index {
+	t.Helper()
+
+	ix := NewIndex()
+	for _, item := range []*LineItem{
+		{ID: "run-of-network"},
+		{ID: "banner", MediaTypes: []openrtb.MarkupType{openrtb.MarkupBanner}},
+		{ID: "banner-300x250", Sizes: []Size{{W: 300, H: 250}}},
+		{ID: "banner-728x90", Sizes: []Size{{W: 728, H: 90}}},
+		{ID: "video", MediaTypes: []openrtb.MarkupType{openrtb.MarkupVideo}},
+		{ID: "video-15s", MediaTypes: []openrtb.MarkupType{openrtb.MarkupVideo}, Duration: 15},
+		{ID: "video-45s", MediaTypes: []openrtb.MarkupType{openrtb.MarkupVideo}, Duration: 45},
+		{ID: "native", MediaTypes: []openrtb.MarkupType{openrtb.MarkupNative}},
+		{ID: "deal", Deals: []string{"1452f.eadb4.f9bc"}},
+		{ID: "us", Countries: []string{"US"}},
+		{ID: "gb", Countries: []string{"GBR"}},
+		{ID: "mobile", DeviceTypes: []openrtb.DeviceType{openrtb.DeviceTypeMobile}},
+		{ID: "ctv", DeviceTypes: []openrtb.DeviceType{openrtb.DeviceTypeConnected}},
+		{ID: "autos", Categories: []openrtb.ContentCategory{"IAB2"}},
+		{ID: "books", Categories: []openrtb.ContentCategory{"IAB1-1"}},
+		{ID: "instream", Filter: targeting.MustCompile(`imp.video.startdelay >= 0`)},
+	} {
+		if err := ix.Upsert(item); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	return ix
+}
+
+func TestIndex_Match(t *testing.T) {
+	ix := seedIndex(t)
+	if exp, got := 16, ix.Len(); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	req := fixture(t, "breq.banner")
+	if exp, got := map[string][]string{
+		"1": {"autos", "banner", "banner-300x250", "run-of-network"},
+	}, matchedIDs(ix.Match(req)); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	req.Device = &openrtb.Device{DeviceType: openrtb.DeviceTypePhone, Geo: &openrtb.Geo{Country: "USA"}}
+	req.Impressions[0].Banner.Formats = []openrtb.Format{{Width: 728, Height: 90}}
+	if exp, got := map[string][]string{
+		"1": {"autos", "banner", "banner-300x250", "banner-728x90", "mobile", "run-of-network", "us"},
+	}, matchedIDs(ix.Match(req)); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	req = fixture(t, "breq.video")
+	if exp, got := map[string][]string{
+		"1": nil,
+		"2": {"deal"},
+		"3": {"autos", "instream", "run-of-network", "video", "video-45s"},
+	}, matchedIDs(ix.Match(req)); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	req.Impressions[0].PMP.Private = 0
+	if exp, got := []string{"autos", "instream", "run-of-network", "video", "video-15s"}, matchedIDs(ix.Match(req))["1"]; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	req = fixture(t, "breq.native")
+	if exp, got := map[string][]string{
+		"1": {"native", "run-of-network"},
+	}, matchedIDs(ix.Match(req)); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestIndex_Upsert(t *testing.T) {
+	ix := seedIndex(t)
+	req := fixture(t, "breq.banner")
+
+	if err := ix.Upsert(&LineItem{}); err != ErrNoID {
+		t.Errorf("expected %v, got %v", ErrNoID, err)
+	}
+
+	// replace
+	if err := ix.Upsert(&LineItem{ID: "banner-728x90", Sizes: []Size{{W: 300, H: 250}}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := ix.Upsert(&LineItem{ID: "banner", MediaTypes: []openrtb.MarkupType{openrtb.MarkupVideo}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := []string{"autos", "banner-300x250", "banner-728x90", "run-of-network"}, matchedIDs(ix.Match(req))["1"]; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := 16, ix.Len(); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	// remove
+	if !ix.Remove("autos") {
+		t.Errorf("expected line item to be removed")
+	}
+	if ix.Remove("autos") {
+		t.Errorf("expected line item to be removed only once")
+	}
+	if ix.Get("autos") != nil {
+		t.Errorf("expected no line item")
+	}
+	if exp, got := []string{"banner-300x250", "banner-728x90", "run-of-network"}, matchedIDs(ix.Match(req))["1"]; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	// reuse slot
+	if err := ix.Upsert(&LineItem{ID: "arts", Categories: []openrtb.ContentCategory{"IAB2-2"}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := []string{"arts", "banner-300x250", "banner-728x90", "run-of-network"}, matchedIDs(ix.Match(req))["1"]; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := 16, ix.Len(); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func BenchmarkIndex_Match(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	pick := func(n int, fn func() any) []any {
+		if rnd.Intn(3) == 0 {
+			return nil
+		}
+		vv := make([]any, 1+rnd.Intn(n))
+		for i := range vv {
+			vv[i] = fn()
+		}
+		return vv
+	}
+
+	sizes := []Size{{300, 250}, {728, 90}, {320, 50}, {160, 600}, {640, 480}, {1920, 1080}}
+	countries := []string{"USA", "GBR", "DEU", "FRA", "CAN", "AUS", "BRA", "JPN"}
+
+	ix := NewIndex()
+	for i := 0; i < 100_000; i++ {
+		item := &LineItem{ID: fmt.Sprintf("li-%d", i)}
+		for _, v := range pick(2, func() any { return openrtb.MarkupType(1 + rnd.Intn(4)) }) {
+			item.MediaTypes = append(item.MediaTypes, v.(openrtb.MarkupType))
+		}
+		for _, v := range pick(3, func() any { return sizes[rnd.Intn(len(sizes))] }) {
+			item.Sizes = append(item.Sizes, v.(Size))
+		}
+		for _, v := range pick(3, func() any { return countries[rnd.Intn(len(countries))] }) {
+			item.Countries = append(item.Countries, v.(string))
+		}
+		for _, v := range pick(2, func() any { return openrtb.DeviceType(1 + rnd.Intn(7)) }) {
+			item.DeviceTypes = append(item.DeviceTypes, v.(openrtb.DeviceType))
+		}
+		for _, v := range pick(4, func() any { return openrtb.ContentCategory(fmt.Sprintf("IAB%d", 1+rnd.Intn(26))) }) {
+			item.Categories = append(item.Categories, v.(openrtb.ContentCategory))
+		}
+		if rnd.Intn(2) == 0 {
+			item.Duration = 5 * (1 + rnd.Intn(12))
+		}
+		if rnd.Intn(100) == 0 {
+			item.Deals = []string{"1452f.eadb4.7aaa"}
+		}
+		if err := ix.Upsert(item); err != nil {
+			b.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	for _, name := range []string{"breq.banner", "breq.video", "breq.native"} {
+		req := fixture(b, name)
+		req.Device = &openrtb.Device{DeviceType: openrtb.DeviceTypePhone, Geo: &openrtb.Geo{Country: "USA"}}
+
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = ix.Match(req)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package openrtb
+
+import "strconv"
+
+// Migration records a deprecated field which was migrated into its
+// replacement.
+type Migration struct {
+	From      string // JSON path of the deprecated field, e.g. "imp[0].video.placement"
+	To        string // JSON path of the replacement, e.g. "imp[0].video.plcmt"
+	Ambiguous bool   // The value was derived from incomplete or conflicting signals
+	Reason    string // Explanation of an ambiguous derivation
+}
+
+// Migrations is the list of migrations applied by Migrate, in document order.
+type Migrations []Migration
+
+// Ambiguous returns the migrations which were derived heuristically and
+// should be treated with caution, e.g. in reporting.
+func (m Migrations) Ambiguous() Migrations {
+	var ambiguous Migrations
+	for _, x := range m {
+		if x.Ambiguous {
+			ambiguous = append(ambiguous, x)
+		}
+	}
+	return ambiguous
+}
+
+// Migrate migrates the deprecated fields of all impressions into their
+// replacements, see Impression.Migrate.
+func (req *BidRequest) Migrate() Migrations {
+	var m Migrations
+	for i := range req.Impressions {
+		req.Impressions[i].migrate(&m, "imp["+strconv.Itoa(i)+"]")
+	}
+	return m
+}
+
+// Migrate migrates the deprecated fields of the impression into their
+// replacements:
+//
+//   - video.plcmt is derived from video.placement, video.startdelay,
+//     video.playbackmethod and instl, following the IAB Tech Lab guidance on
+//     the updated video placement types. The deprecated placement is kept.
+//   - video.protocol is folded into video.protocols.
+//   - The deprecated banner size ranges are represented by format entries,
+//     see Banner.NormalizeFormats. This applies to companion ads as well.
+//
+// Fields which are already set are never overwritten. Migrate is idempotent.
+func (imp *Impression) Migrate() Migrations {
+	var m Migrations
+	imp.migrate(&m, "")
+	return m
+}
+
+func (imp *Impression) migrate(m *Migrations, path string) {
+	if imp.Banner != nil {
+		imp.Banner.migrate(m, joinPath(path, "banner"))
+	}
+	if v := imp.Video; v != nil {
+		vpath := joinPath(path, "video")
+		v.migratePlcmt(m, vpath, imp.Interstitial == 1)
+		v.migrateProtocol(m, vpath)
+		for i := range v.CompanionAds {
+			v.CompanionAds[i].migrate(m, vpath+".companionad["+strconv.Itoa(i)+"]")
+		}
+	}
+	if a := imp.Audio; a != nil {
+		for i := range a.CompanionAds {
+			a.CompanionAds[i].migrate(m, joinPath(path, "audio")+".companionad["+strconv.Itoa(i)+"]")
+		}
+	}
+}
+
+func (v *Video) migratePlcmt(m *Migrations, path string, interstitial bool) {
+	if v.Plcmt != 0 || v.Placement == VideoPlacementUnknown {
+		return
+	}
+
+	x := Migration{From: path + ".placement", To: path + ".plcmt"}
+	switch v.Placement {
+	case VideoPlacementInStream:
+		// in-stream requires sound on or a user initiated playback
+		soundOn, soundOff := 0, 0
+		for _, pm := range v.PlaybackMethods {
+			switch pm {
+			case VideoPlaybackPageLoadSoundOff, VideoPlaybackEnterSoundOff:
+				soundOff++
+			case VideoPlaybackPageLoadSoundOn, VideoPlaybackClickToPlay, VideoPlaybackMouseOver, VideoPlaybackEnterSoundOn:
+				soundOn++
+			}
+		}
+
+		switch {
+		case soundOn == 0 && soundOff == 0:
+			v.Plcmt = VideoPlcmtInstream
+			x.Ambiguous, x.Reason = true, "in-stream placement without playback method"
+		case soundOn == 0:
+			v.Plcmt = VideoPlcmtAccompanyingContent
+			x.Ambiguous, x.Reason = true, "in-stream placement with muted playback"
+		case soundOff != 0:
+			v.Plcmt = VideoPlcmtInstream
+			x.Ambiguous, x.Reason = true, "in-stream placement with muted and unmuted playback methods"
+		default:
+			v.Plcmt = VideoPlcmtInstream
+		}
+	case VideoPlacementInBanner, VideoPlacementInFeed:
+		v.Plcmt = VideoPlcmtNoContent
+	case VideoPlacementInArticle:
+		v.Plcmt = VideoPlcmtNoContent
+		x.Ambiguous, x.Reason = true, "in-article placement may accompany content"
+	case VideoPlacementInterstitial:
+		if interstitial {
+			v.Plcmt = VideoPlcmtInterstitial
+		} else {
+			v.Plcmt = VideoPlcmtAccompanyingContent
+			x.Ambiguous, x.Reason = true, "slider or floating placement without interstitial flag"
+		}
+	default:
+		return
+	}
+
+	if !x.Ambiguous && v.StartDelay != StartDelayPreRoll && v.Plcmt != VideoPlcmtInstream {
+		x.Ambiguous, x.Reason = true, "start delay implies in-stream playback"
+	}
+	*m = append(*m, x)
+}
+
+func (v *Video) migrateProtocol(m *Migrations, path string) {
+	if v.Protocol == ProtocolUnknown {
+		return
+	}
+
+	found := false
+	for _, p := range v.Protocols {
+		found = found || p == v.Protocol
+	}
+	if !found {
+		v.Protocols = append(v.Protocols, v.Protocol)
+	}
+	v.Protocol = ProtocolUnknown
+	*m = append(*m, Migration{From: path + ".protocol", To: path + ".protocols"})
+}
+
+func (b *Banner) migrate(m *Migrations, path string) {
+	hasMax := b.WidthMax > 0 && b.HeightMax > 0
+	hasMin := b.WidthMin > 0 && b.HeightMin > 0
+	if !hasMax && !hasMin || !b.NormalizeFormats() {
+		return
+	}
+
+	// formats cannot express ranges, only their bounds are added while the
+	// deprecated fields are retained
+	var fields []string
+	if hasMax {
+		fields = append(fields, "wmax", "hmax")
+	}
+	if hasMin {
+		fields = append(fields, "wmin", "hmin")
+	}
+	for _, field := range fields {
+		*m = append(*m, Migration{
+			From:      path + "." + field,
+			To:        path + ".format",
+			Ambiguous: true,
+			Reason:    "size range represented by its bounds in format",
+		})
+	}
+}
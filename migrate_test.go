@@ -0,0 +1,110 @@
+package openrtb_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestImpression_Migrate_plcmt(t *testing.T) {
+	for _, tc := range []struct {
+		placement VideoPlacement
+		playback  []VideoPlayback
+		delay     StartDelay
+		instl     int8
+		exp       VideoPlcmt
+		ambiguous bool
+	}{
+		{placement: VideoPlacementInStream, playback: []VideoPlayback{VideoPlaybackPageLoadSoundOn}, exp: VideoPlcmtInstream},
+		{placement: VideoPlacementInStream, playback: []VideoPlayback{VideoPlaybackClickToPlay}, delay: StartDelayGenericMidRoll, exp: VideoPlcmtInstream},
+		{placement: VideoPlacementInStream, exp: VideoPlcmtInstream, ambiguous: true},
+		{placement: VideoPlacementInStream, playback: []VideoPlayback{VideoPlaybackEnterSoundOff}, exp: VideoPlcmtAccompanyingContent, ambiguous: true},
+		{placement: VideoPlacementInStream, playback: []VideoPlayback{VideoPlaybackPageLoadSoundOn, VideoPlaybackPageLoadSoundOff}, exp: VideoPlcmtInstream, ambiguous: true},
+		{placement: VideoPlacementInBanner, exp: VideoPlcmtNoContent},
+		{placement: VideoPlacementInFeed, exp: VideoPlcmtNoContent},
+		{placement: VideoPlacementInFeed, delay: StartDelayGenericPostRoll, exp: VideoPlcmtNoContent, ambiguous: true},
+		{placement: VideoPlacementInArticle, exp: VideoPlcmtNoContent, ambiguous: true},
+		{placement: VideoPlacementInterstitial, instl: 1, exp: VideoPlcmtInterstitial},
+		{placement: VideoPlacementInterstitial, exp: VideoPlcmtAccompanyingContent, ambiguous: true},
+	} {
+		imp := &Impression{ID: "1", Interstitial: tc.instl, Video: &Video{Placement: tc.placement, PlaybackMethods: tc.playback, StartDelay: tc.delay}}
+		migrations := imp.Migrate()
+		if len(migrations) != 1 {
+			t.Fatalf("expected one migration, got %+v", migrations)
+		}
+
+		x := migrations[0]
+		if exp, got := (Migration{From: "video.placement", To: "video.plcmt"}), (Migration{From: x.From, To: x.To}); exp != got {
+			t.Errorf("expected %+v, got %+v", exp, got)
+		}
+		if tc.exp != imp.Video.Plcmt || tc.ambiguous != x.Ambiguous || x.Ambiguous != (x.Reason != "") {
+			t.Errorf("expected %v (ambiguous: %v) for %+v, got %v (%+v)", tc.exp, tc.ambiguous, tc, imp.Video.Plcmt, x)
+		}
+		if exp, got := tc.placement, imp.Video.Placement; exp != got {
+			t.Errorf("expected %v, got %v", exp, got)
+		}
+	}
+
+	// explicit values are kept
+	imp := &Impression{ID: "1", Video: &Video{Placement: VideoPlacementInFeed, Plcmt: VideoPlcmtInstream}}
+	if migrations := imp.Migrate(); len(migrations) != 0 {
+		t.Errorf("expected no migrations, got %+v", migrations)
+	}
+	if exp, got := VideoPlcmtInstream, imp.Video.Plcmt; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestBidRequest_Migrate(t *testing.T) {
+	subject := &BidRequest{ID: "1", Impressions: []Impression{
+		{ID: "1", Banner: &Banner{Width: 728, Height: 90, WidthMin: 468, HeightMin: 60, WidthMax: 970, HeightMax: 90}},
+		{ID: "2", Video: &Video{
+			Placement:       VideoPlacementInStream,
+			PlaybackMethods: []VideoPlayback{VideoPlaybackClickToPlay},
+			Protocol:        ProtocolVAST4,
+			Protocols:       []Protocol{ProtocolVAST3},
+			CompanionAds:    []Banner{{WidthMax: 300, HeightMax: 250}},
+		}},
+		{ID: "3", Banner: &Banner{Width: 300, Height: 250}},
+	}}
+
+	migrations := subject.Migrate()
+	if exp, got := (Migrations{
+		{From: "imp[0].banner.wmax", To: "imp[0].banner.format", Ambiguous: true, Reason: "size range represented by its bounds in format"},
+		{From: "imp[0].banner.hmax", To: "imp[0].banner.format", Ambiguous: true, Reason: "size range represented by its bounds in format"},
+		{From: "imp[0].banner.wmin", To: "imp[0].banner.format", Ambiguous: true, Reason: "size range represented by its bounds in format"},
+		{From: "imp[0].banner.hmin", To: "imp[0].banner.format", Ambiguous: true, Reason: "size range represented by its bounds in format"},
+		{From: "imp[1].video.placement", To: "imp[1].video.plcmt"},
+		{From: "imp[1].video.protocol", To: "imp[1].video.protocols"},
+		{From: "imp[1].video.companionad[0].wmax", To: "imp[1].video.companionad[0].format", Ambiguous: true, Reason: "size range represented by its bounds in format"},
+		{From: "imp[1].video.companionad[0].hmax", To: "imp[1].video.companionad[0].format", Ambiguous: true, Reason: "size range represented by its bounds in format"},
+	}), migrations; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if exp, got := 6, len(migrations.Ambiguous()); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	if exp, got := (&Banner{Width: 728, Height: 90, WidthMin: 468, HeightMin: 60, WidthMax: 970, HeightMax: 90, Formats: []Format{{Width: 728, Height: 90}, {Width: 970, Height: 90}, {Width: 468, Height: 60}}}), subject.Impressions[0].Banner; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	video := subject.Impressions[1].Video
+	if exp, got := []Protocol{ProtocolVAST3, ProtocolVAST4}, video.Protocols; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := ProtocolUnknown, video.Protocol; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := VideoPlcmtInstream, video.Plcmt; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := (&Banner{Width: 300, Height: 250}), subject.Impressions[2].Banner; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	// migration is idempotent
+	if migrations := subject.Migrate(); len(migrations) != 0 {
+		t.Errorf("expected no migrations, got %+v", migrations)
+	}
+}
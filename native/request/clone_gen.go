@@ -0,0 +1,113 @@
+// Code generated by clonegen. DO NOT EDIT.
+
+package request
+
+// Clone returns a deep copy of the Asset.
+func (x *Asset) Clone() *Asset {
+	if x == nil {
+		return nil
+	}
+	c := new(Asset)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Asset) cloneInto(c *Asset) {
+	*c = *x
+	c.Title = x.Title.Clone()
+	c.Image = x.Image.Clone()
+	c.Video = x.Video.Clone()
+	c.Data = x.Data.Clone()
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Data.
+func (x *Data) Clone() *Data {
+	if x == nil {
+		return nil
+	}
+	c := new(Data)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Data) cloneInto(c *Data) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Image.
+func (x *Image) Clone() *Image {
+	if x == nil {
+		return nil
+	}
+	c := new(Image)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Image) cloneInto(c *Image) {
+	*c = *x
+	c.MIMEs = cloneSlice(x.MIMEs)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Request.
+func (x *Request) Clone() *Request {
+	if x == nil {
+		return nil
+	}
+	c := new(Request)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Request) cloneInto(c *Request) {
+	*c = *x
+	if x.Assets != nil {
+		c.Assets = make([]Asset, len(x.Assets))
+		for i := range x.Assets {
+			x.Assets[i].cloneInto(&c.Assets[i])
+		}
+	}
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Title.
+func (x *Title) Clone() *Title {
+	if x == nil {
+		return nil
+	}
+	c := new(Title)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Title) cloneInto(c *Title) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Video.
+func (x *Video) Clone() *Video {
+	if x == nil {
+		return nil
+	}
+	c := new(Video)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Video) cloneInto(c *Video) {
+	*c = *x
+	c.MIMEs = cloneSlice(x.MIMEs)
+	c.Protocols = cloneSlice(x.Protocols)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+func cloneSlice[E any](s []E) []E {
+	if s == nil {
+		return nil
+	}
+	return append(make([]E, 0, len(s)), s...)
+}
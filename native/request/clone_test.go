@@ -0,0 +1,23 @@
+package request_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomlightning/openrtb/v3/internal/clonetest"
+
+	. "github.com/tomlightning/openrtb/v3/native/request"
+)
+
+func TestRequest_Clone(t *testing.T) {
+	subject := new(Request)
+	clonetest.Fill(subject)
+
+	clone := subject.Clone()
+	if !reflect.DeepEqual(subject, clone) {
+		t.Errorf("expected %+v, got %+v", subject, clone)
+	}
+	if paths := clonetest.Aliases(subject, clone); len(paths) != 0 {
+		t.Errorf("expected no aliasing, got %v", paths)
+	}
+}
@@ -0,0 +1,353 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package request
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var adUnitIDNames = map[AdUnitID]string{
+	AdUnitPaidSearch:            "PaidSearch",
+	AdUnitRecommendationWidget:  "RecommendationWidget",
+	AdUnitPromotedListings:      "PromotedListings",
+	AdUnitInAdWithNativeElement: "InAdWithNativeElement",
+	AdUnitCustom:                "Custom",
+}
+
+// String returns the name of the AdUnitID.
+func (x AdUnitID) String() string {
+	return formatEnum(adUnitIDNames, "AdUnitID", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x AdUnitID) IsValid() bool {
+	return x >= AdUnitPaidSearch && x <= AdUnitCustom
+}
+
+// AdUnitIDValues returns all valid AdUnitID values.
+func AdUnitIDValues() []AdUnitID {
+	return []AdUnitID{
+		AdUnitPaidSearch,
+		AdUnitRecommendationWidget,
+		AdUnitPromotedListings,
+		AdUnitInAdWithNativeElement,
+		AdUnitCustom,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseAdUnitID.
+func (x AdUnitID) Text() string {
+	return enumText(adUnitIDNames, x, false)
+}
+
+// ParseAdUnitID returns the AdUnitID for a case-insensitive name or a numeric value.
+func ParseAdUnitID(s string) (AdUnitID, error) {
+	return parseEnum(adUnitIDNames, "AdUnitID", s, false)
+}
+
+// Set parses s into x, see ParseAdUnitID. It implements flag.Value.
+func (x *AdUnitID) Set(s string) (err error) {
+	*x, err = ParseAdUnitID(s)
+	return err
+}
+
+var contextTypeIDNames = map[ContextTypeID]string{
+	ContextTypeContent:           "Content",
+	ContextTypeSocial:            "Social",
+	ContextTypeProduct:           "Product",
+	ContextSubTypeGeneral:        "SubTypeGeneral",
+	ContextSubTypeArticle:        "SubTypeArticle",
+	ContextSubTypeVideo:          "SubTypeVideo",
+	ContextSubTypeAudio:          "SubTypeAudio",
+	ContextSubTypeImage:          "SubTypeImage",
+	ContextSubTypeUserGenerated:  "SubTypeUserGenerated",
+	ContextSubTypeSocial:         "SubTypeSocial",
+	ContextSubTypeEmail:          "SubTypeEmail",
+	ContextSubTypeChat:           "SubTypeChat",
+	ContextSubTypeSelling:        "SubTypeSelling",
+	ContextSubTypeAppStore:       "SubTypeAppStore",
+	ContextSubTypeProductReviews: "SubTypeProductReviews",
+}
+
+// String returns the name of the ContextTypeID.
+func (x ContextTypeID) String() string {
+	return formatEnum(contextTypeIDNames, "ContextTypeID", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x ContextTypeID) IsValid() bool {
+	switch x {
+	case ContextTypeContent, ContextTypeSocial, ContextTypeProduct, ContextSubTypeGeneral, ContextSubTypeArticle, ContextSubTypeVideo, ContextSubTypeAudio, ContextSubTypeImage, ContextSubTypeUserGenerated, ContextSubTypeSocial, ContextSubTypeEmail, ContextSubTypeChat, ContextSubTypeSelling, ContextSubTypeAppStore, ContextSubTypeProductReviews:
+		return true
+	}
+	return false
+}
+
+// ContextTypeIDValues returns all valid ContextTypeID values.
+func ContextTypeIDValues() []ContextTypeID {
+	return []ContextTypeID{
+		ContextTypeContent,
+		ContextTypeSocial,
+		ContextTypeProduct,
+		ContextSubTypeGeneral,
+		ContextSubTypeArticle,
+		ContextSubTypeVideo,
+		ContextSubTypeAudio,
+		ContextSubTypeImage,
+		ContextSubTypeUserGenerated,
+		ContextSubTypeSocial,
+		ContextSubTypeEmail,
+		ContextSubTypeChat,
+		ContextSubTypeSelling,
+		ContextSubTypeAppStore,
+		ContextSubTypeProductReviews,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseContextTypeID.
+func (x ContextTypeID) Text() string {
+	return enumText(contextTypeIDNames, x, false)
+}
+
+// ParseContextTypeID returns the ContextTypeID for a case-insensitive name or a numeric value.
+func ParseContextTypeID(s string) (ContextTypeID, error) {
+	return parseEnum(contextTypeIDNames, "ContextTypeID", s, false)
+}
+
+// Set parses s into x, see ParseContextTypeID. It implements flag.Value.
+func (x *ContextTypeID) Set(s string) (err error) {
+	*x, err = ParseContextTypeID(s)
+	return err
+}
+
+var dataTypeIDNames = map[DataTypeID]string{
+	DataTypeSponsored:      "Sponsored",
+	DataTypeDesc:           "Desc",
+	DataTypeRating:         "Rating",
+	DataTypeLikes:          "Likes",
+	DataTypeDownloads:      "Downloads",
+	DataTypePrice:          "Price",
+	DataTypeSalePrice:      "SalePrice",
+	DataTypePhone:          "Phone",
+	DataTypeAddress:        "Address",
+	DataTypeDescAdditional: "DescAdditional",
+	DataTypeDisplayURL:     "DisplayURL",
+	DataTypeCTADesc:        "CTADesc",
+}
+
+// String returns the name of the DataTypeID.
+func (x DataTypeID) String() string {
+	return formatEnum(dataTypeIDNames, "DataTypeID", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x DataTypeID) IsValid() bool {
+	return x >= DataTypeSponsored && x <= DataTypeCTADesc
+}
+
+// DataTypeIDValues returns all valid DataTypeID values.
+func DataTypeIDValues() []DataTypeID {
+	return []DataTypeID{
+		DataTypeSponsored,
+		DataTypeDesc,
+		DataTypeRating,
+		DataTypeLikes,
+		DataTypeDownloads,
+		DataTypePrice,
+		DataTypeSalePrice,
+		DataTypePhone,
+		DataTypeAddress,
+		DataTypeDescAdditional,
+		DataTypeDisplayURL,
+		DataTypeCTADesc,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseDataTypeID.
+func (x DataTypeID) Text() string {
+	return enumText(dataTypeIDNames, x, false)
+}
+
+// ParseDataTypeID returns the DataTypeID for a case-insensitive name or a numeric value.
+func ParseDataTypeID(s string) (DataTypeID, error) {
+	return parseEnum(dataTypeIDNames, "DataTypeID", s, false)
+}
+
+// Set parses s into x, see ParseDataTypeID. It implements flag.Value.
+func (x *DataTypeID) Set(s string) (err error) {
+	*x, err = ParseDataTypeID(s)
+	return err
+}
+
+var imageTypeIDNames = map[ImageTypeID]string{
+	ImageTypeIcon: "Icon",
+	ImageTypeLogo: "Logo",
+	ImageTypeMain: "Main",
+}
+
+// String returns the name of the ImageTypeID.
+func (x ImageTypeID) String() string {
+	return formatEnum(imageTypeIDNames, "ImageTypeID", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x ImageTypeID) IsValid() bool {
+	return x >= ImageTypeIcon && x <= ImageTypeMain
+}
+
+// ImageTypeIDValues returns all valid ImageTypeID values.
+func ImageTypeIDValues() []ImageTypeID {
+	return []ImageTypeID{
+		ImageTypeIcon,
+		ImageTypeLogo,
+		ImageTypeMain,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseImageTypeID.
+func (x ImageTypeID) Text() string {
+	return enumText(imageTypeIDNames, x, false)
+}
+
+// ParseImageTypeID returns the ImageTypeID for a case-insensitive name or a numeric value.
+func ParseImageTypeID(s string) (ImageTypeID, error) {
+	return parseEnum(imageTypeIDNames, "ImageTypeID", s, false)
+}
+
+// Set parses s into x, see ParseImageTypeID. It implements flag.Value.
+func (x *ImageTypeID) Set(s string) (err error) {
+	*x, err = ParseImageTypeID(s)
+	return err
+}
+
+var layoutIDNames = map[LayoutID]string{
+	LayoutContentWall:          "ContentWall",
+	LayoutAppWall:              "AppWall",
+	LayoutNewsFeed:             "NewsFeed",
+	LayoutChatList:             "ChatList",
+	LayoutCarousel:             "Carousel",
+	LayoutContentStream:        "ContentStream",
+	LayoutGridAdjoiningContent: "GridAdjoiningContent",
+}
+
+// String returns the name of the LayoutID.
+func (x LayoutID) String() string {
+	return formatEnum(layoutIDNames, "LayoutID", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x LayoutID) IsValid() bool {
+	return x >= LayoutContentWall && x <= LayoutGridAdjoiningContent
+}
+
+// LayoutIDValues returns all valid LayoutID values.
+func LayoutIDValues() []LayoutID {
+	return []LayoutID{
+		LayoutContentWall,
+		LayoutAppWall,
+		LayoutNewsFeed,
+		LayoutChatList,
+		LayoutCarousel,
+		LayoutContentStream,
+		LayoutGridAdjoiningContent,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParseLayoutID.
+func (x LayoutID) Text() string {
+	return enumText(layoutIDNames, x, false)
+}
+
+// ParseLayoutID returns the LayoutID for a case-insensitive name or a numeric value.
+func ParseLayoutID(s string) (LayoutID, error) {
+	return parseEnum(layoutIDNames, "LayoutID", s, false)
+}
+
+// Set parses s into x, see ParseLayoutID. It implements flag.Value.
+func (x *LayoutID) Set(s string) (err error) {
+	*x, err = ParseLayoutID(s)
+	return err
+}
+
+var placementTypeIDNames = map[PlacementTypeID]string{
+	PlacementTypeInFeed:         "InFeed",
+	PlacementTypeAtomic:         "Atomic",
+	PlacementTypeOutside:        "Outside",
+	PlacementTypeRecommendation: "Recommendation",
+}
+
+// String returns the name of the PlacementTypeID.
+func (x PlacementTypeID) String() string {
+	return formatEnum(placementTypeIDNames, "PlacementTypeID", x)
+}
+
+// IsValid returns true if x is defined by the standard.
+func (x PlacementTypeID) IsValid() bool {
+	return x >= PlacementTypeInFeed && x <= PlacementTypeRecommendation
+}
+
+// PlacementTypeIDValues returns all valid PlacementTypeID values.
+func PlacementTypeIDValues() []PlacementTypeID {
+	return []PlacementTypeID{
+		PlacementTypeInFeed,
+		PlacementTypeAtomic,
+		PlacementTypeOutside,
+		PlacementTypeRecommendation,
+	}
+}
+
+// Text returns the name of x, or its number if it has none.
+// Unlike String, the result can be parsed with ParsePlacementTypeID.
+func (x PlacementTypeID) Text() string {
+	return enumText(placementTypeIDNames, x, false)
+}
+
+// ParsePlacementTypeID returns the PlacementTypeID for a case-insensitive name or a numeric value.
+func ParsePlacementTypeID(s string) (PlacementTypeID, error) {
+	return parseEnum(placementTypeIDNames, "PlacementTypeID", s, false)
+}
+
+// Set parses s into x, see ParsePlacementTypeID. It implements flag.Value.
+func (x *PlacementTypeID) Set(s string) (err error) {
+	*x, err = ParsePlacementTypeID(s)
+	return err
+}
+
+type enumInt interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+func formatEnum[T enumInt](names map[T]string, typ string, x T) string {
+	if s, ok := names[x]; ok {
+		return s
+	}
+	return typ + "(" + strconv.FormatInt(int64(x), 10) + ")"
+}
+
+// enumText returns the name of x, or its number if it has none. If zeroUnset
+// is set, the zero value only marks an unset field and has no name.
+func enumText[T enumInt](names map[T]string, x T, zeroUnset bool) string {
+	if s, ok := names[x]; ok && (x != 0 || !zeroUnset) {
+		return s
+	}
+	return strconv.FormatInt(int64(x), 10)
+}
+
+func parseEnum[T enumInt](names map[T]string, typ, s string, zeroUnset bool) (T, error) {
+	for x, name := range names {
+		if strings.EqualFold(name, s) && (x != 0 || !zeroUnset) {
+			return x, nil
+		}
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && int64(T(n)) == n {
+		return T(n), nil
+	}
+	return 0, fmt.Errorf("%w: %s %q", ErrEnumName, typ, s)
+}
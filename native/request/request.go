@@ -1,6 +1,13 @@
 package request
 
-import "github.com/goccy/go-json"
+import (
+	"errors"
+
+	"github.com/goccy/go-json"
+)
+
+// ErrEnumName is returned when parsing an unknown enum name.
+var ErrEnumName = errors.New("request: unknown enum name")
 
 // LayoutID enum.
 type LayoutID int
@@ -1,6 +1,7 @@
 package request_test
 
 import (
+	"errors"
 	"os"
 	"reflect"
 	"testing"
@@ -44,3 +45,30 @@ func fixture(path string, v interface{}) error {
 	}
 	return json.Unmarshal(bin, v)
 }
+
+func TestContextTypeID(t *testing.T) {
+	if exp, got := "Social", ContextTypeSocial.String(); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := "SubTypeSocial", ContextSubTypeSocial.String(); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	x, err := ParseContextTypeID("subtypechat")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := ContextSubTypeChat, x; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if _, err := ParseContextTypeID("feed"); !errors.Is(err, ErrEnumName) {
+		t.Errorf("expected %v, got %v", ErrEnumName, err)
+	}
+
+	if ContextTypeID(4).IsValid() {
+		t.Errorf("expected %v to be invalid", ContextTypeID(4))
+	}
+	if exp, got := 15, len(ContextTypeIDValues()); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
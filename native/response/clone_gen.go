@@ -0,0 +1,128 @@
+// Code generated by clonegen. DO NOT EDIT.
+
+package response
+
+// Clone returns a deep copy of the Asset.
+func (x *Asset) Clone() *Asset {
+	if x == nil {
+		return nil
+	}
+	c := new(Asset)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Asset) cloneInto(c *Asset) {
+	*c = *x
+	c.Title = x.Title.Clone()
+	c.Image = x.Image.Clone()
+	c.Video = x.Video.Clone()
+	c.Data = x.Data.Clone()
+	c.Link = x.Link.Clone()
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Data.
+func (x *Data) Clone() *Data {
+	if x == nil {
+		return nil
+	}
+	c := new(Data)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Data) cloneInto(c *Data) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Image.
+func (x *Image) Clone() *Image {
+	if x == nil {
+		return nil
+	}
+	c := new(Image)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Image) cloneInto(c *Image) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Link.
+func (x *Link) Clone() *Link {
+	if x == nil {
+		return nil
+	}
+	c := new(Link)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Link) cloneInto(c *Link) {
+	*c = *x
+	c.ClickTrackers = cloneSlice(x.ClickTrackers)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Response.
+func (x *Response) Clone() *Response {
+	if x == nil {
+		return nil
+	}
+	c := new(Response)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Response) cloneInto(c *Response) {
+	*c = *x
+	if x.Assets != nil {
+		c.Assets = make([]Asset, len(x.Assets))
+		for i := range x.Assets {
+			x.Assets[i].cloneInto(&c.Assets[i])
+		}
+	}
+	x.Link.cloneInto(&c.Link)
+	c.ImpTrackers = cloneSlice(x.ImpTrackers)
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Title.
+func (x *Title) Clone() *Title {
+	if x == nil {
+		return nil
+	}
+	c := new(Title)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Title) cloneInto(c *Title) {
+	*c = *x
+	c.Ext = cloneSlice(x.Ext)
+}
+
+// Clone returns a deep copy of the Video.
+func (x *Video) Clone() *Video {
+	if x == nil {
+		return nil
+	}
+	c := new(Video)
+	x.cloneInto(c)
+	return c
+}
+
+func (x *Video) cloneInto(c *Video) {
+	*c = *x
+}
+
+func cloneSlice[E any](s []E) []E {
+	if s == nil {
+		return nil
+	}
+	return append(make([]E, 0, len(s)), s...)
+}
@@ -0,0 +1,23 @@
+package response_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomlightning/openrtb/v3/internal/clonetest"
+
+	. "github.com/tomlightning/openrtb/v3/native/response"
+)
+
+func TestResponse_Clone(t *testing.T) {
+	subject := new(Response)
+	clonetest.Fill(subject)
+
+	clone := subject.Clone()
+	if !reflect.DeepEqual(subject, clone) {
+		t.Errorf("expected %+v, got %+v", subject, clone)
+	}
+	if paths := clonetest.Aliases(subject, clone); len(paths) != 0 {
+		t.Errorf("expected no aliasing, got %v", paths)
+	}
+}
@@ -0,0 +1,134 @@
+package openrtb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/goccy/go-json"
+)
+
+// NormalizeOptions configures the Normalize methods.
+type NormalizeOptions struct {
+	// PreferExt resolves conflicts in favour of the ext value. By default, a
+	// field which is already set is kept.
+	PreferExt bool
+	// Clean removes the promoted members from the ext objects. Members which
+	// conflict with a field and were not applied are retained.
+	Clean bool
+}
+
+// Promotion records an ext member which was lifted into a field.
+type Promotion struct {
+	From     string // JSON path of the ext member, e.g. "regs.ext.gdpr"
+	To       string // JSON path of the field, e.g. "regs.gdpr"
+	Applied  bool   // The field was set to the ext value
+	Conflict bool   // The field was already set to a different value
+}
+
+// Promotions is the list of promotions applied by Normalize, in document order.
+type Promotions []Promotion
+
+// Conflicts returns the promotions which found a different value in place.
+func (p Promotions) Conflicts() Promotions {
+	var conflicts Promotions
+	for _, x := range p {
+		if x.Conflict {
+			conflicts = append(conflicts, x)
+		}
+	}
+	return conflicts
+}
+
+// Normalize promotes the members which were conventionally passed in ext
+// objects before 2.6 into their fields: regs.ext.gdpr, regs.ext.us_privacy,
+// user.ext.consent, user.ext.eids, source.ext.schain and imp.ext.gpid.
+// Members with zero values are left in place. Invalid members are reported
+// as ErrExtInvalid, after all other members have been promoted.
+func (req *BidRequest) Normalize(opt *NormalizeOptions) (Promotions, error) {
+	n := newNormalizer(opt)
+
+	if regs := req.Regulations; regs != nil {
+		lift(n, &regs.Ext, ExtRegulations, "regs", "gdpr", &regs.GDPR)
+		lift(n, &regs.Ext, ExtRegulations, "regs", "us_privacy", &regs.USPrivacy)
+	}
+	if user := req.User; user != nil {
+		lift(n, &user.Ext, ExtUser, "user", "consent", &user.Consent)
+		lift(n, &user.Ext, ExtUser, "user", "eids", &user.EIDs)
+	}
+	if src := req.Source; src != nil {
+		lift(n, &src.Ext, ExtSource, "source", "schain", &src.SChain)
+	}
+	for i := range req.Impressions {
+		imp := &req.Impressions[i]
+		lift(n, &imp.Ext, ExtImpression, "imp["+strconv.Itoa(i)+"]", "gpid", &imp.GPID)
+	}
+	return n.promotions, errors.Join(n.errs...)
+}
+
+// Normalize promotes the members which were conventionally passed in ext
+// objects before 2.6 into their fields: bid.ext.dsa. See BidRequest.Normalize.
+func (res *BidResponse) Normalize(opt *NormalizeOptions) (Promotions, error) {
+	n := newNormalizer(opt)
+
+	for i := range res.SeatBids {
+		sb := &res.SeatBids[i]
+		for j := range sb.Bids {
+			bid := &sb.Bids[j]
+			lift(n, &bid.Ext, ExtBid, "seatbid["+strconv.Itoa(i)+"].bid["+strconv.Itoa(j)+"]", "dsa", &bid.DSA)
+		}
+	}
+	return n.promotions, errors.Join(n.errs...)
+}
+
+type normalizer struct {
+	opt        NormalizeOptions
+	promotions Promotions
+	errs       []error
+}
+
+func newNormalizer(opt *NormalizeOptions) *normalizer {
+	n := new(normalizer)
+	if opt != nil {
+		n.opt = *opt
+	}
+	return n
+}
+
+// lift promotes the member key of the ext of the object at path into field.
+func lift[T any](n *normalizer, ext *json.RawMessage, kind ExtKind, path, key string, field *T) {
+	if len(*ext) == 0 {
+		return
+	}
+
+	v, err := GetExt[T](kind, *ext, key)
+	if errors.Is(err, ErrExtNotFound) {
+		return
+	} else if err != nil {
+		n.errs = append(n.errs, fmt.Errorf("%w: %s.ext.%s: %v", ErrExtInvalid, path, key, err))
+		return
+	}
+	if reflect.ValueOf(&v).Elem().IsZero() {
+		return
+	}
+
+	p := Promotion{From: path + ".ext." + key, To: joinPath(path, key)}
+	switch {
+	case reflect.ValueOf(field).Elem().IsZero():
+		*field, p.Applied = v, true
+	case reflect.DeepEqual(*field, v):
+	default:
+		p.Conflict = true
+		if n.opt.PreferExt {
+			*field, p.Applied = v, true
+		}
+	}
+
+	if n.opt.Clean && (p.Applied || !p.Conflict) {
+		if *ext, err = SetExt(*ext, key, nil); err != nil {
+			n.errs = append(n.errs, fmt.Errorf("%w: %s.ext: %v", ErrExtInvalid, path, err))
+		}
+	}
+	n.promotions = append(n.promotions, p)
+}
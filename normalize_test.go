@@ -0,0 +1,160 @@
+package openrtb_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestBidRequest_Normalize(t *testing.T) {
+	var subject *BidRequest
+	if err := fixture("breq.v25", &subject); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	promotions, err := subject.Normalize(nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := (Promotions{
+		{From: "regs.ext.gdpr", To: "regs.gdpr", Applied: true},
+		{From: "regs.ext.us_privacy", To: "regs.us_privacy", Applied: true},
+		{From: "user.ext.consent", To: "user.consent", Applied: true},
+		{From: "user.ext.eids", To: "user.eids", Applied: true},
+		{From: "source.ext.schain", To: "source.schain", Applied: true},
+		{From: "imp[0].ext.gpid", To: "imp[0].gpid", Applied: true},
+	}), promotions; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	if regs := subject.Regulations; regs.GDPR != 1 || regs.USPrivacy != "1YNN" || regs.Ext == nil {
+		t.Errorf("expected promoted regulations, got %+v", regs)
+	}
+	if exp, got := "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA", subject.User.Consent; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := []EID{{Source: "id5-sync.com", UIDs: []UID{{ID: "ID5-ZHMOaW5vZmRh", AType: 1}}}}, subject.User.EIDs; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if exp, got := "exchange.example.com", subject.Source.SChain.Nodes[0].ASI; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := "/1234/home#banner", subject.Impressions[0].GPID; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	// normalization is idempotent
+	promotions, err = subject.Normalize(nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, p := range promotions {
+		if p.Applied || p.Conflict {
+			t.Errorf("expected no changes, got %+v", p)
+		}
+	}
+}
+
+func TestBidRequest_Normalize_clean(t *testing.T) {
+	var subject *BidRequest
+	if err := fixture("breq.v25", &subject); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := subject.Normalize(&NormalizeOptions{Clean: true}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if subject.Regulations.Ext != nil || subject.Source.Ext != nil || subject.Impressions[0].Ext != nil {
+		t.Errorf("expected promoted members to be removed, got %s, %s, %s", subject.Regulations.Ext, subject.Source.Ext, subject.Impressions[0].Ext)
+	}
+	if exp, got := `{"keep":true}`, string(subject.User.Ext); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestBidRequest_Normalize_conflicts(t *testing.T) {
+	newRequest := func() *BidRequest {
+		return &BidRequest{
+			Regulations: &Regulations{USPrivacy: "1YYN", Ext: json.RawMessage(`{"gdpr":0,"us_privacy":"1NNN"}`)},
+			User:        &User{Consent: "same", Ext: json.RawMessage(`{"consent":"same"}`)},
+			Impressions: []Impression{{ID: "1", Ext: json.RawMessage(`{"gpid":1}`)}},
+		}
+	}
+
+	subject := newRequest()
+	promotions, err := subject.Normalize(nil)
+	if !errors.Is(err, ErrExtInvalid) {
+		t.Errorf("expected %v, got %v", ErrExtInvalid, err)
+	}
+	if exp, got := (Promotions{
+		{From: "regs.ext.us_privacy", To: "regs.us_privacy", Conflict: true},
+		{From: "user.ext.consent", To: "user.consent"},
+	}), promotions; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if exp, got := "1YYN", subject.Regulations.USPrivacy; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := 1, len(promotions.Conflicts()); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	subject = newRequest()
+	promotions, _ = subject.Normalize(&NormalizeOptions{PreferExt: true, Clean: true})
+	if exp, got := (Promotion{From: "regs.ext.us_privacy", To: "regs.us_privacy", Applied: true, Conflict: true}), promotions[0]; exp != got {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if exp, got := (&Regulations{USPrivacy: "1NNN", Ext: json.RawMessage(`{"gdpr":0}`)}), subject.Regulations; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if exp, got := `{"gpid":1}`, string(subject.Impressions[0].Ext); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	subject = newRequest()
+	promotions, _ = subject.Normalize(&NormalizeOptions{Clean: true})
+	if exp, got := (Promotion{From: "regs.ext.us_privacy", To: "regs.us_privacy", Conflict: true}), promotions[0]; exp != got {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if exp, got := (&Regulations{USPrivacy: "1YYN", Ext: json.RawMessage(`{"gdpr":0,"us_privacy":"1NNN"}`)}), subject.Regulations; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if subject.User.Ext != nil {
+		t.Errorf("expected equal consent to be removed, got %s", subject.User.Ext)
+	}
+}
+
+func TestBidResponse_Normalize(t *testing.T) {
+	var subject *BidResponse
+	if err := json.Unmarshal([]byte(`{"id":"1","seatbid":[{"bid":[
+		{"id":"1","impid":"1","price":1},
+		{"id":"2","impid":"1","price":1,"ext":{"dsa":{"behalf":"Advertiser","paid":"Agency","transparency":[{"domain":"dsp.example.com","dsaparams":[1,2]}],"adrender":1}}}
+	]}]}`), &subject); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	promotions, err := subject.Normalize(&NormalizeOptions{Clean: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := (Promotions{{From: "seatbid[0].bid[1].ext.dsa", To: "seatbid[0].bid[1].dsa", Applied: true}}), promotions; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	bid := subject.SeatBids[0].Bids[1]
+	if exp, got := (&DSA{
+		Behalf:       "Advertiser",
+		Paid:         "Agency",
+		Transparency: []DSATransparency{{Domain: "dsp.example.com", Params: []int{1, 2}}},
+		AdRender:     1,
+	}), bid.DSA; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if bid.Ext != nil {
+		t.Errorf("expected no ext, got %s", bid.Ext)
+	}
+}
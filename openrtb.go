@@ -1,6 +1,10 @@
 package openrtb
 
-import "github.com/goccy/go-json"
+import (
+	"errors"
+
+	"github.com/goccy/go-json"
+)
 
 // ContentCategory as defined in section 5.1
 type ContentCategory string
@@ -426,6 +430,9 @@ const (
 	ContentCategoryCopyrightInfringement ContentCategory = "IAB26-4"
 )
 
+// ErrEnumName is returned when parsing an unknown enum name.
+var ErrEnumName = errors.New("openrtb: unknown enum name")
+
 // BannerType as defined in section 5.2.
 type BannerType int8
 
@@ -466,13 +473,14 @@ type AdPosition int8
 
 // 5.4 Ad Position
 const (
-	AdPositionUnknown    AdPosition = 0
-	AdPositionAboveFold  AdPosition = 1
-	AdPositionBelowFold  AdPosition = 3
-	AdPositionHeader     AdPosition = 4
-	AdPositionFooter     AdPosition = 5
-	AdPositionSidebar    AdPosition = 6
-	AdPositionFullscreen AdPosition = 7
+	AdPositionUnknown      AdPosition = 0
+	AdPositionAboveFold    AdPosition = 1
+	AdPositionMaybeVisible AdPosition = 2 // Deprecated: may or may not be initially visible depending on screen size/resolution.
+	AdPositionBelowFold    AdPosition = 3
+	AdPositionHeader       AdPosition = 4
+	AdPositionFooter       AdPosition = 5
+	AdPositionSidebar      AdPosition = 6
+	AdPositionFullscreen   AdPosition = 7
 )
 
 // ExpDir as defined in section 5.5.
@@ -776,17 +784,17 @@ type Geo struct {
 	Ext           json.RawMessage `json:"ext,omitempty"`           // -
 	Country       string          `json:"country,omitempty"`       // Country using ISO 3166-1 Alpha 3
 	Region        string          `json:"region,omitempty"`        // Region using ISO 3166-2
-	RegionFIPS104 string          `json:"regionFIPS104,omitempty"` // Region of a country using FIPS 10-4
+	RegionFIPS104 string          `json:"regionfips104,omitempty"` // Region of a country using FIPS 10-4
 	Metro         string          `json:"metro,omitempty"`         // -
 	City          string          `json:"city,omitempty"`          // -
 	ZIP           string          `json:"zip,omitempty"`           // -
 	Accuracy      int             `json:"accuracy,omitempty"`      // Estimated location accuracy in meters; recommended when lat/lon are specified and derived from a device’s location services
 	LastFix       int             `json:"lastfix,omitempty"`       // Number of seconds since this geolocation fix was established.
-	Latitude      float32         `json:"lat,omitempty"`           // Latitude from -90 to 90
-	Longitude     float32         `json:"lon,omitempty"`           // Longitude from -180 to 180
+	Latitude      float64         `json:"lat,omitempty"`           // Latitude from -90 to 90
+	Longitude     float64         `json:"lon,omitempty"`           // Longitude from -180 to 180
 	Type          LocationType    `json:"type,omitempty"`          // Indicate the source of the geo data
 	IPService     IPLocation      `json:"ipservice,omitempty"`     // Service or provider used to determine geolocation from IP address if applicable
-	UTCOffset     int16           `json:"utcoffset,omitempty"`     // Local time as the number +/- of minutes from UTC
+	UTCOffset     int             `json:"utcoffset,omitempty"`     // Local time as the number +/- of minutes from UTC
 }
 
 // User object contains information known or derived about the human user of the device (i.e., the
@@ -801,11 +809,31 @@ type User struct {
 	Gender      string          `json:"gender,omitempty"`     // Gender ("M": male, "F" female, "O" Other)
 	Keywords    string          `json:"keywords,omitempty"`   // Comma separated list of keywords, interests, or intent
 	CustomData  string          `json:"customdata,omitempty"` // Optional feature to pass bidder data that was set in the exchange's cookie. The string must be in base85 cookie safe characters and be in any format. Proper JSON encoding must be used to include "escaped" quotation marks.
+	Consent     string          `json:"consent,omitempty"`    // GDPR consent string, if applicable, complying with the IAB TCF.
 	Geo         *Geo            `json:"geo,omitempty"`
 	Data        []Data          `json:"data,omitempty"`
+	EIDs        []EID           `json:"eids,omitempty"` // Extended identifiers from third-party identity providers.
 	Ext         json.RawMessage `json:"ext,omitempty"`
 }
 
+// EID object (extended identifier) supports the passing of user identifiers from multiple
+// identity providers, each with their own set of identifiers.
+type EID struct {
+	UIDs        []UID           `json:"uids,omitempty"`     // Array of extended ID UID objects from the given source.
+	Ext         json.RawMessage `json:"ext,omitempty"`      // -
+	Source      string          `json:"source,omitempty"`   // Source or technology provider responsible for the set of included IDs, expressed as a top-level domain.
+	Inserter    string          `json:"inserter,omitempty"` // The canonical domain name of the entity that caused the ID array element to be added.
+	Matcher     string          `json:"matcher,omitempty"`  // Technology providing the match method as defined in mm.
+	MatchMethod int             `json:"mm,omitempty"`       // Match method used by the matcher.
+}
+
+// UID object contains a single user identifier provided as part of extended identifiers.
+type UID struct {
+	Ext   json.RawMessage `json:"ext,omitempty"`   // -
+	ID    string          `json:"id,omitempty"`    // The identifier for the user.
+	AType int8            `json:"atype,omitempty"` // Type of user agent the ID is from, where 1 = device, 2 = person-based, 3 = publisher-provided.
+}
+
 // Data and segment objects together allow additional data about the user to be specified. This data
 // may be from multiple sources whether from the exchange itself or third party providers as specified by
 // the id field. A bid request can mix data objects from multiple providers. The specific data providers in
@@ -841,12 +869,38 @@ type Regulations struct {
 // These are typically used in an array for an impression where multiple sizes are permitted.
 // It is recommended that either the w/h pair or the wratio/hratio/wmin set (i.e., for Flex Ads) be specified.
 type Format struct {
-	Ext         json.RawMessage `json:"ext,omitempty"`     // -
-	Width       int16           `json:"w,omitempty"`       // Width in device independent pixels (DIPS).
-	Height      int16           `json:"h,omitempty"`       // Height in device independent pixels (DIPS).
-	WidthRatio  int16           `json:"wratio,omitempty"`  // Relative width when expressing size as a ratio.
-	HeightRatio int16           `json:"hration,omitempty"` // Relative height when expressing size as a ratio.
-	WidthMin    int16           `json:"wmin,omitempty"`    // The minimum width in device independent pixels (DIPS) at which the ad will be displayed the size is expressed as a ratio.
+	Ext         json.RawMessage `json:"ext,omitempty"`    // -
+	Width       int             `json:"w,omitempty"`      // Width in device independent pixels (DIPS).
+	Height      int             `json:"h,omitempty"`      // Height in device independent pixels (DIPS).
+	WidthRatio  int             `json:"wratio,omitempty"` // Relative width when expressing size as a ratio.
+	HeightRatio int             `json:"hratio,omitempty"` // Relative height when expressing size as a ratio.
+	WidthMin    int             `json:"wmin,omitempty"`   // The minimum width in device independent pixels (DIPS) at which the ad will be displayed the size is expressed as a ratio.
+}
+
+type jsonFormat Format
+
+// UnmarshalJSON implements json.Unmarshaler. For compatibility, the relative
+// height is also accepted under the misspelled "hration" key, which previous
+// versions of this package used.
+func (f *Format) UnmarshalJSON(data []byte) error {
+	var h struct {
+		jsonFormat
+		LegacyHeightRatio int `json:"hration"`
+	}
+	if err := json.Unmarshal(data, &h); err != nil {
+		return err
+	}
+
+	*f = (Format)(h.jsonFormat)
+	if f.HeightRatio == 0 {
+		f.HeightRatio = h.LegacyHeightRatio
+	}
+	return nil
+}
+
+// IsFlex returns true if the format expresses the size as a ratio.
+func (f *Format) IsFlex() bool {
+	return f.WidthRatio > 0 && f.HeightRatio > 0
 }
 
 // PodSequence identifies the pod sequence field, for use in video content streams with one or more ad pods as defined in Adcom1.0
@@ -893,6 +947,8 @@ const (
 	CategoryTaxonomyIABAudience11 CategoryTaxonomy = 4 // 4	IAB Audience Taxonomy 1.1.
 	CategoryTaxonomyIABContent21  CategoryTaxonomy = 5 // 5	IAB Content Category Taxonomy 2.1.
 	CategoryTaxonomyIABContent22  CategoryTaxonomy = 6 // 6	IAB Content Category Taxonomy 2.2
+	CategoryTaxonomyIABContent3   CategoryTaxonomy = 7 // 7	IAB Content Taxonomy 3.0.
+	CategoryTaxonomyIABProduct2   CategoryTaxonomy = 8 // 8	IAB Ad Product Taxonomy 2.0.
 )
 
 // VideoPlcmt represents the the various types of video placements in accordance with updated IAB Digital Video Guidelines.
@@ -906,6 +962,17 @@ const (
 	VideoPlcmtNoContent           VideoPlcmt = 4
 )
 
+// UASource identifies the source of data used to create a UserAgent as defined in AdCOM 1.0.
+type UASource int
+
+// User-Agent Source options as defined in AdCOM 1.0
+const (
+	UASourceUnknown     UASource = 0 // 0	Unspecified/unknown
+	UASourceLowEntropy  UASource = 1 // 1	User-Agent Client Hints (only low-entropy headers were available)
+	UASourceHighEntropy UASource = 2 // 2	User-Agent Client Hints (with high-entropy headers available)
+	UASourceUserAgent   UASource = 3 // 3	Parsed from User-Agent header (the same string carried by the UA field)
+)
+
 // ChannelEntity describes the network or channel an ad will be displayed on. (Reffer Section 3.2.23 and 3.2.24 OpenRTB_2.6)
 type ChannelEntity struct {
 	ID     string          `json:"id,omitempty"`
@@ -0,0 +1,450 @@
+package openrtb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// Patch errors
+var (
+	ErrPatchInvalid   = errors.New("openrtb: invalid patch")
+	ErrPatchPath      = errors.New("openrtb: patch path not found")
+	ErrPatchTest      = errors.New("openrtb: patch test failed")
+	ErrExtNotAnObject = errors.New("openrtb: ext is not an object")
+)
+
+// MergeExt deep-merges src into dst and returns the result. Objects are merged
+// recursively, all other values in src replace those in dst and null values
+// in src remove the corresponding members, following RFC 7386.
+func MergeExt(dst, src json.RawMessage) (json.RawMessage, error) {
+	if len(src) == 0 {
+		return dst, nil
+	}
+
+	target, err := decodeExtTree(dst)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := decodeTree(src)
+	if err != nil {
+		return nil, err
+	}
+	return encodeExtTree(mergePatch(target, patch))
+}
+
+// SetExtPath encodes v and stores it at the given path within a raw ext object,
+// creating intermediate objects as required.
+//
+//	ext, err = openrtb.SetExtPath(ext, "1.00", "prebid", "targeting", "hb_pb")
+func SetExtPath(ext json.RawMessage, v interface{}, path ...string) (json.RawMessage, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: empty path", ErrPatchPath)
+	}
+
+	tree, err := decodeExtTree(ext)
+	if err != nil {
+		return nil, err
+	}
+	val, err := toTree(v)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, ok := tree.(map[string]interface{})
+	if !ok {
+		return nil, ErrExtNotAnObject
+	}
+	root := obj
+	for i, key := range path[:len(path)-1] {
+		next, ok := obj[key]
+		if !ok || next == nil {
+			next = make(map[string]interface{})
+			obj[key] = next
+		}
+		if obj, ok = next.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("%w: %s is not an object", ErrPatchPath, strings.Join(path[:i+1], "."))
+		}
+	}
+	obj[path[len(path)-1]] = val
+	return encodeExtTree(root)
+}
+
+// DeleteExtKey removes the member at the given path within a raw ext object.
+// Missing paths are ignored.
+func DeleteExtKey(ext json.RawMessage, path ...string) (json.RawMessage, error) {
+	if len(path) == 0 || len(ext) == 0 {
+		return ext, nil
+	}
+
+	tree, err := decodeExtTree(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, ok := tree.(map[string]interface{})
+	if !ok {
+		return nil, ErrExtNotAnObject
+	}
+	root := obj
+	for _, key := range path[:len(path)-1] {
+		if obj, ok = obj[key].(map[string]interface{}); !ok {
+			return ext, nil
+		}
+	}
+	delete(obj, path[len(path)-1])
+	return encodeExtTree(root)
+}
+
+// ApplyMergePatch applies an RFC 7386 JSON merge patch to v and returns the
+// result decoded into a new value of the same type.
+func ApplyMergePatch[T any](v *T, patch []byte) (*T, error) {
+	doc, err := toTree(v)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decodeTree(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPatchInvalid, err)
+	}
+	return fromTree[T](mergePatch(doc, p))
+}
+
+// ApplyJSONPatch applies an RFC 6902 JSON patch to v and returns the result
+// decoded into a new value of the same type. The patch is applied atomically,
+// v remains unchanged if any of the operations fail.
+func ApplyJSONPatch[T any](v *T, patch []byte) (*T, error) {
+	var ops []struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		From  string          `json:"from"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPatchInvalid, err)
+	}
+
+	doc, err := toTree(v)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range ops {
+		var val interface{}
+		if op.Op == "add" || op.Op == "replace" || op.Op == "test" {
+			if op.Value == nil {
+				return nil, fmt.Errorf("%w: operation %d (%s) has no value", ErrPatchInvalid, i, op.Op)
+			}
+			if val, err = decodeTree(op.Value); err != nil {
+				return nil, fmt.Errorf("%w: operation %d: %v", ErrPatchInvalid, i, err)
+			}
+		}
+
+		switch op.Op {
+		case "add":
+			doc, err = pointerAdd(doc, op.Path, val)
+		case "remove":
+			doc, _, err = pointerRemove(doc, op.Path)
+		case "replace":
+			if doc, _, err = pointerRemove(doc, op.Path); err == nil {
+				doc, err = pointerAdd(doc, op.Path, val)
+			}
+		case "move":
+			if strings.HasPrefix(op.Path, op.From+"/") {
+				err = fmt.Errorf("%w: cannot move %s into itself", ErrPatchInvalid, op.From)
+				break
+			}
+			var moved interface{}
+			if doc, moved, err = pointerRemove(doc, op.From); err == nil {
+				doc, err = pointerAdd(doc, op.Path, moved)
+			}
+		case "copy":
+			var src interface{}
+			if src, err = pointerGet(doc, op.From); err == nil {
+				var copied interface{}
+				if copied, err = toTree(src); err == nil {
+					doc, err = pointerAdd(doc, op.Path, copied)
+				}
+			}
+		case "test":
+			var cur interface{}
+			if cur, err = pointerGet(doc, op.Path); err == nil && !treeEqual(cur, val) {
+				err = fmt.Errorf("%w: %s", ErrPatchTest, op.Path)
+			}
+		default:
+			err = fmt.Errorf("%w: unknown operation %q", ErrPatchInvalid, op.Op)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return fromTree[T](doc)
+}
+
+// --------------------------------------------------------------------
+
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{}, len(p))
+	}
+	for key, val := range p {
+		if val == nil {
+			delete(t, key)
+		} else {
+			t[key] = mergePatch(t[key], val)
+		}
+	}
+	return t
+}
+
+// parsePointer parses an RFC 6901 JSON pointer into its reference tokens.
+func parsePointer(ptr string) ([]string, error) {
+	if ptr == "" {
+		return nil, nil
+	}
+	if ptr[0] != '/' {
+		return nil, fmt.Errorf("%w: invalid pointer %q", ErrPatchInvalid, ptr)
+	}
+
+	tokens := strings.Split(ptr[1:], "/")
+	for i, tok := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func arrayIndex(tok string, n int, allowEnd bool) (int, error) {
+	if tok == "-" && allowEnd {
+		return n, nil
+	}
+
+	pos, err := strconv.Atoi(tok)
+	if err != nil || pos < 0 || pos > n || (pos == n && !allowEnd) || (len(tok) > 1 && tok[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrPatchPath, tok)
+	}
+	return pos, nil
+}
+
+func pointerGet(doc interface{}, ptr string) (interface{}, error) {
+	tokens, err := parsePointer(ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tok := range tokens {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			val, ok := node[tok]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrPatchPath, ptr)
+			}
+			doc = val
+		case []interface{}:
+			pos, err := arrayIndex(tok, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[pos]
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrPatchPath, ptr)
+		}
+	}
+	return doc, nil
+}
+
+// pointerAdd adds val at ptr and returns the (possibly replaced) document.
+func pointerAdd(doc interface{}, ptr string, val interface{}) (interface{}, error) {
+	tokens, err := parsePointer(ptr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return val, nil
+	}
+
+	parent, err := pointerGet(doc, ptr[:strings.LastIndexByte(ptr, '/')])
+	if err != nil {
+		return nil, err
+	}
+
+	last := tokens[len(tokens)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = val
+	case []interface{}:
+		pos, err := arrayIndex(last, len(node), true)
+		if err != nil {
+			return nil, err
+		}
+		node = append(node, nil)
+		copy(node[pos+1:], node[pos:])
+		node[pos] = val
+		return replaceParent(doc, tokens[:len(tokens)-1], node)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrPatchPath, ptr)
+	}
+	return doc, nil
+}
+
+// pointerRemove removes the value at ptr and returns the (possibly replaced)
+// document and the removed value.
+func pointerRemove(doc interface{}, ptr string) (interface{}, interface{}, error) {
+	tokens, err := parsePointer(ptr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, doc, nil
+	}
+
+	parent, err := pointerGet(doc, ptr[:strings.LastIndexByte(ptr, '/')])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	last := tokens[len(tokens)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		val, ok := node[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s", ErrPatchPath, ptr)
+		}
+		delete(node, last)
+		return doc, val, nil
+	case []interface{}:
+		pos, err := arrayIndex(last, len(node), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		val := node[pos]
+		node = append(node[:pos:pos], node[pos+1:]...)
+		doc, err = replaceParent(doc, tokens[:len(tokens)-1], node)
+		return doc, val, err
+	}
+	return nil, nil, fmt.Errorf("%w: %s", ErrPatchPath, ptr)
+}
+
+// replaceParent replaces the array at the given tokens, which is necessary
+// as slices may be re-allocated on modification.
+func replaceParent(doc interface{}, tokens []string, arr []interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return arr, nil
+	}
+
+	parent := doc
+	for _, tok := range tokens[:len(tokens)-1] {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			parent = node[tok]
+		case []interface{}:
+			pos, _ := strconv.Atoi(tok)
+			parent = node[pos]
+		}
+	}
+
+	last := tokens[len(tokens)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = arr
+	case []interface{}:
+		pos, _ := strconv.Atoi(last)
+		node[pos] = arr
+	}
+	return doc, nil
+}
+
+// --------------------------------------------------------------------
+
+// decodeTree decodes data into generic JSON values, preserving numbers.
+func decodeTree(data []byte) (interface{}, error) {
+	var tree interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func decodeExtTree(ext json.RawMessage) (interface{}, error) {
+	if len(ext) == 0 || string(ext) == "null" {
+		return make(map[string]interface{}), nil
+	}
+	return decodeTree(ext)
+}
+
+func encodeExtTree(tree interface{}) (json.RawMessage, error) {
+	if obj, ok := tree.(map[string]interface{}); ok && len(obj) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(tree)
+}
+
+// toTree converts v into generic JSON values.
+func toTree(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeTree(data)
+}
+
+func fromTree[T any](tree interface{}) (*T, error) {
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	v := new(T)
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// treeEqual compares two generic JSON values semantically.
+func treeEqual(a, b interface{}) bool {
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fx, err1 := x.Float64()
+		fy, err2 := y.Float64()
+		return err1 == nil && err2 == nil && fx == fy
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			if w, ok := y[k]; !ok || !treeEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !treeEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
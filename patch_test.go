@@ -0,0 +1,166 @@
+package openrtb_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestMergeExt(t *testing.T) {
+	for _, tc := range []struct {
+		dst, src, exp string
+	}{
+		{`{"bidder":{"placement":1,"keep":true}}`, `{"bidder":{"placement":2}}`, `{"bidder":{"keep":true,"placement":2}}`},
+		{`{"a":[1,2],"b":1}`, `{"a":[3],"b":null}`, `{"a":[3]}`},
+		{``, `{"prebid":{"debug":true}}`, `{"prebid":{"debug":true}}`},
+		{`{"a":1}`, ``, `{"a":1}`},
+		{`{"a":1}`, `{"a":null}`, ``},
+		{`{"n":12345678901234567890}`, `{"m":0.1}`, `{"m":0.1,"n":12345678901234567890}`},
+	} {
+		got, err := MergeExt(json.RawMessage(tc.dst), json.RawMessage(tc.src))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if exp := tc.exp; exp != string(got) {
+			t.Errorf("expected %v, got %v", exp, string(got))
+		}
+	}
+}
+
+func TestSetExtPath(t *testing.T) {
+	got, err := SetExtPath(json.RawMessage(`{"prebid":{"debug":true}}`), "1.00", "prebid", "targeting", "hb_pb")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp := `{"prebid":{"debug":true,"targeting":{"hb_pb":"1.00"}}}`; exp != string(got) {
+		t.Errorf("expected %v, got %v", exp, string(got))
+	}
+
+	if _, err := SetExtPath(got, 1, "prebid", "debug", "x"); !errors.Is(err, ErrPatchPath) {
+		t.Errorf("expected %v, got %v", ErrPatchPath, err)
+	}
+	if _, err := SetExtPath(json.RawMessage(`[1]`), 1, "x"); !errors.Is(err, ErrExtNotAnObject) {
+		t.Errorf("expected %v, got %v", ErrExtNotAnObject, err)
+	}
+}
+
+func TestDeleteExtKey(t *testing.T) {
+	got, err := DeleteExtKey(json.RawMessage(`{"prebid":{"debug":true,"targeting":{"hb_pb":"1.00"}}}`), "prebid", "targeting")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp := `{"prebid":{"debug":true}}`; exp != string(got) {
+		t.Errorf("expected %v, got %v", exp, string(got))
+	}
+
+	got, err = DeleteExtKey(got, "missing", "key")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp := `{"prebid":{"debug":true}}`; exp != string(got) {
+		t.Errorf("expected %v, got %v", exp, string(got))
+	}
+
+	got, err = DeleteExtKey(got, "prebid")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected nil, got %s", got)
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	var subject *BidRequest
+	if err := fixture("breq.banner", &subject); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got, err := ApplyMergePatch(subject, []byte(`{"tmax":250,"user":null,"device":{"ifa":"xyz"},"ext":{"prebid":{"debug":true}}}`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp := 250; exp != got.TimeMax {
+		t.Errorf("expected %v, got %v", exp, got.TimeMax)
+	}
+	if got.User != nil {
+		t.Errorf("expected no user, got %+v", got.User)
+	}
+	if exp := "xyz"; exp != got.Device.IFA {
+		t.Errorf("expected %v, got %v", exp, got.Device.IFA)
+	}
+	if exp := subject.Device.IP; exp != got.Device.IP {
+		t.Errorf("expected %v, got %v", exp, got.Device.IP)
+	}
+	if exp := `{"prebid":{"debug":true}}`; exp != string(got.Ext) {
+		t.Errorf("expected %v, got %v", exp, string(got.Ext))
+	}
+	if subject.User == nil || subject.TimeMax != 120 {
+		t.Errorf("expected original to remain unchanged, got %+v", subject)
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	var subject *BidRequest
+	if err := fixture("breq.video", &subject); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got, err := ApplyJSONPatch(subject, []byte(`[
+		{"op":"test","path":"/imp/0/id","value":"1"},
+		{"op":"remove","path":"/imp/1"},
+		{"op":"replace","path":"/tmax","value":300},
+		{"op":"add","path":"/imp/0/ext","value":{"bidder":{"placement":"abc"}}},
+		{"op":"add","path":"/bcat","value":["IAB25"]},
+		{"op":"add","path":"/bcat/-","value":"IAB26"},
+		{"op":"copy","from":"/imp/0/video/mimes","path":"/imp/1/video/mimes"},
+		{"op":"move","from":"/site/publisher/name","path":"/site/name"}
+	]`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if exp, got := []string{"1", "3"}, []string{got.Impressions[0].ID, got.Impressions[1].ID}; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp := 300; exp != got.TimeMax {
+		t.Errorf("expected %v, got %v", exp, got.TimeMax)
+	}
+	if exp := `{"bidder":{"placement":"abc"}}`; exp != string(got.Impressions[0].Ext) {
+		t.Errorf("expected %v, got %v", exp, string(got.Impressions[0].Ext))
+	}
+	if exp := []ContentCategory{ContentCategoryNonStandardContent, ContentCategoryAnyIllegalContent}; !reflect.DeepEqual(exp, got.BlockedCategories) {
+		t.Errorf("expected %v, got %v", exp, got.BlockedCategories)
+	}
+	if exp := "Publisher A"; exp != got.Site.Name {
+		t.Errorf("expected %v, got %v", exp, got.Site.Name)
+	}
+	if exp := ""; exp != got.Site.Publisher.Name {
+		t.Errorf("expected %v, got %v", exp, got.Site.Publisher.Name)
+	}
+	if exp := 3; exp != len(subject.Impressions) {
+		t.Errorf("expected original to remain unchanged, got %v", len(subject.Impressions))
+	}
+}
+
+func TestApplyJSONPatch_errors(t *testing.T) {
+	subject := &BidRequest{ID: "1", Impressions: []Impression{{ID: "1"}}}
+	for patch, exp := range map[string]error{
+		`{"op":"add"}`:                                           ErrPatchInvalid,
+		`[{"op":"jump","path":"/id"}]`:                           ErrPatchInvalid,
+		`[{"op":"add","path":"/id"}]`:                            ErrPatchInvalid,
+		`[{"op":"remove","path":"/site"}]`:                       ErrPatchPath,
+		`[{"op":"add","path":"/imp/5","value":{"id":"2"}}]`:      ErrPatchPath,
+		`[{"op":"test","path":"/id","value":"2"}]`:               ErrPatchTest,
+		`[{"op":"move","from":"/imp","path":"/imp/0/x"}]`:        ErrPatchInvalid,
+		`[{"op":"replace","path":"/imp/01","value":{"id":"2"}}]`: ErrPatchPath,
+	} {
+		if _, err := ApplyJSONPatch(subject, []byte(patch)); !errors.Is(err, exp) {
+			t.Errorf("expected %v, got %v for %s", exp, err, patch)
+		}
+	}
+}
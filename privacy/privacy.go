@@ -0,0 +1,263 @@
+// Package privacy enforces the privacy signals of OpenRTB bid requests.
+//
+// Enforce inspects the COPPA, GDPR and US Privacy signals of the Regulations
+// object, including the regs.ext members used before 2.6, as well as the LMT
+// and DNT flags of the Device, and scrubs personal data from the request
+// accordingly.
+package privacy
+
+import (
+	"math"
+	"net/netip"
+	"strings"
+
+	"github.com/tomlightning/openrtb/v3"
+)
+
+// Rule identifies the privacy signal that triggered a modification.
+type Rule string
+
+// Rule options, in order of precedence.
+const (
+	RuleCOPPA     Rule = "coppa"      // Regulations.COPPA is set
+	RuleGDPR      Rule = "gdpr"       // Regulations.GDPR is set and HasConsent does not report consent
+	RuleUSPrivacy Rule = "us_privacy" // Regulations.USPrivacy signals an opt-out of sale
+	RuleLMT       Rule = "lmt"        // Device.LMT is set
+	RuleDNT       Rule = "dnt"        // Device.DNT is set
+)
+
+// Action describes a single modification.
+type Action struct {
+	Rule  Rule   // Rule which caused the modification
+	Field string // JSON path of the modified field, e.g. "device.ifa"
+	Op    string // Operation: "removed", "truncated" or "rounded"
+}
+
+// Audit is the list of modifications applied by Enforce.
+type Audit []Action
+
+// Fields returns the paths of all fields modified by rule.
+func (a Audit) Fields(rule Rule) []string {
+	var fields []string
+	for _, act := range a {
+		if act.Rule == rule {
+			fields = append(fields, act.Field)
+		}
+	}
+	return fields
+}
+
+// Options configure Enforce.
+type Options struct {
+	// HasConsent reports whether the request carries a valid GDPR consent for
+	// personal data processing. When nil, requests subject to GDPR are always
+	// scrubbed, consent strings are not interpreted.
+	HasConsent func(*openrtb.BidRequest) bool
+
+	// IPv4Bits and IPv6Bits are the prefix lengths retained when truncating
+	// IP addresses. Default: openrtb.DefaultIPv4Prefix and
+	// openrtb.DefaultIPv6Prefix.
+	IPv4Bits, IPv6Bits int
+
+	// GeoDecimals is the number of decimals latitudes and longitudes are
+	// rounded to. Default: 2 (approx. 1.1 km).
+	GeoDecimals int
+}
+
+func (o *Options) norm() *Options {
+	var opt Options
+	if o != nil {
+		opt = *o
+	}
+	if opt.GeoDecimals <= 0 {
+		opt.GeoDecimals = 2
+	}
+	if opt.HasConsent == nil {
+		opt.HasConsent = func(*openrtb.BidRequest) bool { return false }
+	}
+	return &opt
+}
+
+// scrub defines the categories of data removed by a rule.
+type scrub struct {
+	IP       bool // truncate IP addresses
+	DeviceID bool // remove IFA and hashed device IDs
+	Geo      bool // round lat/lon
+	UserID   bool // remove user IDs
+	UserDemo bool // remove year of birth and gender
+}
+
+var scrubs = map[Rule]scrub{
+	RuleCOPPA:     {IP: true, DeviceID: true, Geo: true, UserID: true, UserDemo: true},
+	RuleGDPR:      {IP: true, DeviceID: true, Geo: true, UserID: true, UserDemo: true},
+	RuleUSPrivacy: {IP: true, DeviceID: true, Geo: true, UserID: true, UserDemo: true},
+	RuleLMT:       {DeviceID: true, UserID: true},
+	RuleDNT:       {DeviceID: true, UserID: true},
+}
+
+// Rules returns the rules which apply to the request, in order of precedence.
+func Rules(req *openrtb.BidRequest, opts *Options) []Rule {
+	opt := opts.norm()
+
+	var rules []Rule
+	if regs := req.Regulations; regs != nil {
+		if regs.COPPA == 1 {
+			rules = append(rules, RuleCOPPA)
+		}
+		if gdprApplies(regs) && !opt.HasConsent(req) {
+			rules = append(rules, RuleGDPR)
+		}
+		if USPrivacyOptOut(usPrivacy(regs)) {
+			rules = append(rules, RuleUSPrivacy)
+		}
+	}
+	if dev := req.Device; dev != nil {
+		if dev.LMT == 1 {
+			rules = append(rules, RuleLMT)
+		}
+		if dev.DNT == 1 {
+			rules = append(rules, RuleDNT)
+		}
+	}
+	return rules
+}
+
+// gdprApplies returns true if the request is subject to GDPR, as signalled by
+// regs.gdpr or, before 2.6, by regs.ext.gdpr.
+func gdprApplies(regs *openrtb.Regulations) bool {
+	if regs.GDPR != 0 {
+		return regs.GDPR == 1
+	}
+	gdpr, _ := openrtb.GetExt[int8](openrtb.ExtRegulations, regs.Ext, "gdpr")
+	return gdpr == 1
+}
+
+// usPrivacy returns regs.us_privacy or, before 2.6, regs.ext.us_privacy.
+func usPrivacy(regs *openrtb.Regulations) string {
+	if regs.USPrivacy != "" {
+		return regs.USPrivacy
+	}
+	s, _ := openrtb.GetExt[string](openrtb.ExtRegulations, regs.Ext, "us_privacy")
+	return s
+}
+
+// USPrivacyOptOut returns true if a US Privacy string (e.g. "1YYN") signals
+// that the user has opted out of the sale of personal information.
+func USPrivacyOptOut(s string) bool {
+	return len(s) == 4 && s[0] == '1' && strings.ToUpper(s[2:3]) == "Y"
+}
+
+// Enforce scrubs personal data from req according to its privacy signals and
+// returns an audit of all modifications. The request is modified in place,
+// use Clone to retain the original.
+func Enforce(req *openrtb.BidRequest, opts *Options) Audit {
+	if req == nil {
+		return nil
+	}
+
+	opt := opts.norm()
+	e := &enforcer{opt: opt}
+	for _, rule := range Rules(req, opt) {
+		e.rule = rule
+		e.apply(req, scrubs[rule])
+	}
+	return e.audit
+}
+
+type enforcer struct {
+	opt   *Options
+	rule  Rule
+	audit Audit
+}
+
+func (e *enforcer) record(field, op string) {
+	e.audit = append(e.audit, Action{Rule: e.rule, Field: field, Op: op})
+}
+
+func (e *enforcer) apply(req *openrtb.BidRequest, s scrub) {
+	if dev := req.Device; dev != nil {
+		if s.IP {
+			e.truncateIP(&dev.IP, "device.ip")
+			e.truncateIP(&dev.IPv6, "device.ipv6")
+		}
+		if s.DeviceID {
+			e.remove(&dev.IFA, "device.ifa")
+			e.remove(&dev.IDSHA1, "device.didsha1")
+			e.remove(&dev.IDMD5, "device.didmd5")
+			e.remove(&dev.PIDSHA1, "device.dpidsha1")
+			e.remove(&dev.PIDMD5, "device.dpidmd5")
+			e.remove(&dev.MacSHA1, "device.macsha1")
+			e.remove(&dev.MacMD5, "device.macmd5")
+		}
+		if s.Geo {
+			e.roundGeo(dev.Geo, "device.geo")
+		}
+	}
+
+	if user := req.User; user != nil {
+		if s.UserID {
+			e.remove(&user.ID, "user.id")
+			e.remove(&user.BuyerUID, "user.buyeruid")
+			e.remove(&user.BuyerID, "user.buyerid")
+			if len(user.EIDs) != 0 {
+				user.EIDs = nil
+				e.record("user.eids", "removed")
+			}
+		}
+		if s.UserDemo {
+			if user.YearOfBirth != 0 {
+				user.YearOfBirth = 0
+				e.record("user.yob", "removed")
+			}
+			e.remove(&user.Gender, "user.gender")
+		}
+		if s.Geo {
+			e.roundGeo(user.Geo, "user.geo")
+		}
+	}
+}
+
+func (e *enforcer) remove(s *string, field string) {
+	if *s != "" {
+		*s = ""
+		e.record(field, "removed")
+	}
+}
+
+func (e *enforcer) truncateIP(s *string, field string) {
+	if *s == "" {
+		return
+	}
+
+	addr, err := netip.ParseAddr(*s)
+	if err != nil {
+		*s = ""
+		e.record(field, "removed")
+		return
+	}
+
+	if masked := openrtb.TruncateIP(addr, e.opt.IPv4Bits, e.opt.IPv6Bits).String(); masked != *s {
+		*s = masked
+		e.record(field, "truncated")
+	}
+}
+
+func (e *enforcer) roundGeo(geo *openrtb.Geo, field string) {
+	if geo == nil {
+		return
+	}
+
+	scale := math.Pow10(e.opt.GeoDecimals)
+	round := func(f float64) float64 {
+		return math.Round(f*scale) / scale
+	}
+
+	if lat := round(geo.Latitude); lat != geo.Latitude {
+		geo.Latitude = lat
+		e.record(field+".lat", "rounded")
+	}
+	if lon := round(geo.Longitude); lon != geo.Longitude {
+		geo.Longitude = lon
+		e.record(field+".lon", "rounded")
+	}
+}
@@ -0,0 +1,196 @@
+package privacy_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomlightning/openrtb/v3"
+
+	. "github.com/tomlightning/openrtb/v3/privacy"
+)
+
+func newRequest() *openrtb.BidRequest {
+	return &openrtb.BidRequest{
+		ID: "req",
+		Device: &openrtb.Device{
+			IP:     "192.168.1.73",
+			IPv6:   "2001:db8:85a3:1234:5678:8a2e:370:7334",
+			IFA:    "AA000DFE74168477C70D291f574D344790E0BB11",
+			IDSHA1: "sha1",
+			MacMD5: "md5",
+			UA:     "Mozilla/5.0",
+			Geo:    &openrtb.Geo{Latitude: 51.51234, Longitude: -0.12789, Country: "GBR"},
+		},
+		User: &openrtb.User{
+			ID:          "uid",
+			BuyerUID:    "buid",
+			YearOfBirth: 1984,
+			Gender:      "F",
+			Keywords:    "sports",
+			EIDs:        []openrtb.EID{{Source: "id5-sync.com", UIDs: []openrtb.UID{{ID: "ID5-ZHMOaW5vZmRh", AType: 1}}}},
+		},
+	}
+}
+
+func TestEnforce_noSignals(t *testing.T) {
+	req := newRequest()
+	if audit := Enforce(req, nil); len(audit) != 0 {
+		t.Errorf("expected no modifications, got %v", audit)
+	}
+	if exp := newRequest(); !reflect.DeepEqual(exp, req) {
+		t.Errorf("expected %+v, got %+v", exp, req)
+	}
+}
+
+func TestEnforce_coppa(t *testing.T) {
+	req := newRequest()
+	req.Regulations = &openrtb.Regulations{COPPA: 1}
+
+	audit := Enforce(req, nil)
+	if exp, got := []string{
+		"device.ip", "device.ipv6", "device.ifa", "device.didsha1", "device.macmd5",
+		"device.geo.lat", "device.geo.lon",
+		"user.id", "user.buyeruid", "user.eids", "user.yob", "user.gender",
+	}, audit.Fields(RuleCOPPA); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	dev := req.Device
+	if exp, got := "192.168.1.0", dev.IP; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := "2001:db8:85a3:1200::", dev.IPv6; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if dev.IFA != "" || dev.IDSHA1 != "" || dev.MacMD5 != "" {
+		t.Errorf("expected device IDs to be removed, got %+v", dev)
+	}
+	if exp, got := (&openrtb.Geo{Latitude: 51.51, Longitude: -0.13, Country: "GBR"}), dev.Geo; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if exp, got := "Mozilla/5.0", dev.UA; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	if exp, got := (&openrtb.User{Keywords: "sports"}), req.User; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	// enforcement is idempotent
+	if audit := Enforce(req, nil); len(audit) != 0 {
+		t.Errorf("expected no modifications, got %v", audit)
+	}
+}
+
+func TestEnforce_gdpr(t *testing.T) {
+	req := newRequest()
+	req.Regulations = &openrtb.Regulations{GDPR: 1}
+
+	consent := &Options{HasConsent: func(*openrtb.BidRequest) bool { return true }}
+	if audit := Enforce(req, consent); len(audit) != 0 {
+		t.Errorf("expected no modifications, got %v", audit)
+	}
+
+	// consent strings are not interpreted, they may encode a refusal
+	req.User.Consent = "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"
+	if audit := Enforce(req, nil); len(audit.Fields(RuleGDPR)) != 12 {
+		t.Errorf("expected 12 modifications, got %v", audit)
+	}
+}
+
+func TestRules_ext(t *testing.T) {
+	req := newRequest()
+	req.Regulations = &openrtb.Regulations{Ext: []byte(`{"gdpr":1,"us_privacy":"1YYN"}`)}
+	if exp, got := []Rule{RuleGDPR, RuleUSPrivacy}, Rules(req, nil); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	req.Regulations = &openrtb.Regulations{USPrivacy: "1YNN", Ext: []byte(`{"gdpr":0,"us_privacy":"1YYN"}`)}
+	if got := Rules(req, nil); len(got) != 0 {
+		t.Errorf("expected no rules, got %v", got)
+	}
+}
+
+func TestEnforce_lmt(t *testing.T) {
+	req := newRequest()
+	req.Device.LMT = 1
+	req.Device.DNT = 1
+
+	audit := Enforce(req, nil)
+	if exp, got := (Audit{
+		{Rule: RuleLMT, Field: "device.ifa", Op: "removed"},
+		{Rule: RuleLMT, Field: "device.didsha1", Op: "removed"},
+		{Rule: RuleLMT, Field: "device.macmd5", Op: "removed"},
+		{Rule: RuleLMT, Field: "user.id", Op: "removed"},
+		{Rule: RuleLMT, Field: "user.buyeruid", Op: "removed"},
+		{Rule: RuleLMT, Field: "user.eids", Op: "removed"},
+	}), audit; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := "192.168.1.73", req.Device.IP; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := 1984, req.User.YearOfBirth; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestEnforce_usPrivacy(t *testing.T) {
+	req := newRequest()
+	req.Regulations = &openrtb.Regulations{USPrivacy: "1YNN"}
+	if audit := Enforce(req, nil); len(audit) != 0 {
+		t.Errorf("expected no modifications, got %v", audit)
+	}
+
+	req.Regulations.USPrivacy = "1YYN"
+	audit := Enforce(req, &Options{IPv4Bits: 16})
+	if exp, got := 12, len(audit.Fields(RuleUSPrivacy)); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := "192.168.0.0", req.Device.IP; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestEnforce_precedence(t *testing.T) {
+	req := newRequest()
+	req.Regulations = &openrtb.Regulations{COPPA: 1, GDPR: 1}
+	req.Device.LMT = 1
+
+	if exp, got := []Rule{RuleCOPPA, RuleGDPR, RuleLMT}, Rules(req, nil); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	audit := Enforce(req, nil)
+	for _, act := range audit {
+		if act.Rule != RuleCOPPA {
+			t.Errorf("expected all modifications by %v, got %+v", RuleCOPPA, act)
+		}
+	}
+}
+
+func TestEnforce_invalidIP(t *testing.T) {
+	req := newRequest()
+	req.Regulations = &openrtb.Regulations{COPPA: 1}
+	req.Device.IP = "not-an-ip"
+
+	audit := Enforce(req, nil)
+	if exp, got := (Action{Rule: RuleCOPPA, Field: "device.ip", Op: "removed"}), audit[0]; exp != got {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+}
+
+func TestUSPrivacyOptOut(t *testing.T) {
+	for s, exp := range map[string]bool{
+		"":     false,
+		"1---": false,
+		"1YNN": false,
+		"1YYN": true,
+		"1nyy": true,
+		"2YYN": false,
+	} {
+		if got := USPrivacyOptOut(s); exp != got {
+			t.Errorf("%q: expected %v, got %v", s, exp, got)
+		}
+	}
+}
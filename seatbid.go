@@ -2,6 +2,7 @@ package openrtb
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/goccy/go-json"
 )
@@ -39,3 +40,12 @@ func (sb *SeatBid) Validate() error {
 
 	return nil
 }
+
+func (sb *SeatBid) validateAll(errs *violations, path string) {
+	if len(sb.Bids) == 0 {
+		errs.add(path, ErrInvalidSeatBidBid)
+	}
+	for i := range sb.Bids {
+		sb.Bids[i].validateAll(errs, fmt.Sprintf("%s.bid[%d]", path, i))
+	}
+}
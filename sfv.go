@@ -0,0 +1,177 @@
+package openrtb
+
+import (
+	"errors"
+	"strings"
+)
+
+var errSFInvalid = errors.New("invalid structured field")
+
+// sfItem is an item of an RFC 8941 structured field. Bare items are kept in
+// their textual form: strings are unescaped, booleans are "?0" or "?1".
+type sfItem struct {
+	Value  string
+	Params map[string]string
+}
+
+// parseSFList parses an RFC 8941 list of items. Inner lists are not supported.
+func parseSFList(s string) ([]sfItem, error) {
+	p := &sfParser{s: s}
+	p.skipSP()
+
+	var items []sfItem
+	for p.i < len(p.s) {
+		item, err := p.item()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		p.skipOWS()
+		if p.i == len(p.s) {
+			break
+		}
+		if p.s[p.i] != ',' {
+			return nil, errSFInvalid
+		}
+		p.i++
+		p.skipOWS()
+		if p.i == len(p.s) {
+			return nil, errSFInvalid // trailing comma
+		}
+	}
+	return items, nil
+}
+
+// parseSFItem parses a single RFC 8941 item.
+func parseSFItem(s string) (sfItem, error) {
+	p := &sfParser{s: s}
+	p.skipSP()
+
+	item, err := p.item()
+	if err != nil {
+		return item, err
+	}
+
+	p.skipSP()
+	if p.i != len(p.s) {
+		return item, errSFInvalid
+	}
+	return item, nil
+}
+
+type sfParser struct {
+	s string
+	i int
+}
+
+func (p *sfParser) skipSP() {
+	for p.i < len(p.s) && p.s[p.i] == ' ' {
+		p.i++
+	}
+}
+
+func (p *sfParser) skipOWS() {
+	for p.i < len(p.s) && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+func (p *sfParser) item() (sfItem, error) {
+	val, err := p.bareItem()
+	if err != nil {
+		return sfItem{}, err
+	}
+
+	item := sfItem{Value: val}
+	for p.i < len(p.s) && p.s[p.i] == ';' {
+		p.i++
+		p.skipSP()
+
+		key := p.key()
+		if key == "" {
+			return item, errSFInvalid
+		}
+
+		val := "?1"
+		if p.i < len(p.s) && p.s[p.i] == '=' {
+			p.i++
+			if val, err = p.bareItem(); err != nil {
+				return item, err
+			}
+		}
+		if item.Params == nil {
+			item.Params = make(map[string]string, 1)
+		}
+		item.Params[key] = val
+	}
+	return item, nil
+}
+
+func (p *sfParser) bareItem() (string, error) {
+	if p.i == len(p.s) {
+		return "", errSFInvalid
+	}
+
+	switch c := p.s[p.i]; {
+	case c == '"':
+		return p.string()
+	case c == '?':
+		if p.i+1 < len(p.s) && (p.s[p.i+1] == '0' || p.s[p.i+1] == '1') {
+			p.i += 2
+			return p.s[p.i-2 : p.i], nil
+		}
+		return "", errSFInvalid
+	case c == '-' || isDigit(c):
+		return p.span(func(c byte) bool { return c == '-' || c == '.' || isDigit(c) }), nil
+	case c == '*' || isAlpha(c):
+		return p.span(isTokenChar), nil
+	}
+	return "", errSFInvalid
+}
+
+func (p *sfParser) string() (string, error) {
+	var b strings.Builder
+	for p.i++; p.i < len(p.s); p.i++ {
+		switch c := p.s[p.i]; {
+		case c == '\\':
+			p.i++
+			if p.i == len(p.s) || (p.s[p.i] != '"' && p.s[p.i] != '\\') {
+				return "", errSFInvalid
+			}
+			b.WriteByte(p.s[p.i])
+		case c == '"':
+			p.i++
+			return b.String(), nil
+		case c < 0x20 || c > 0x7e:
+			return "", errSFInvalid
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", errSFInvalid // unterminated
+}
+
+func (p *sfParser) key() string {
+	if p.i == len(p.s) || !(p.s[p.i] == '*' || (p.s[p.i] >= 'a' && p.s[p.i] <= 'z')) {
+		return ""
+	}
+	return p.span(func(c byte) bool {
+		return (c >= 'a' && c <= 'z') || isDigit(c) || c == '_' || c == '-' || c == '.' || c == '*'
+	})
+}
+
+func (p *sfParser) span(accept func(byte) bool) string {
+	start := p.i
+	for p.i < len(p.s) && accept(p.s[p.i]) {
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+func isAlpha(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+
+func isTokenChar(c byte) bool {
+	return isAlpha(c) || isDigit(c) || strings.IndexByte("!#$%&'*+-.^_`|~:/", c) >= 0
+}
@@ -7,5 +7,26 @@ type Source struct {
 	Ext               json.RawMessage `json:"ext,omitempty"`    // Placeholder for exchange-specific extensions to OpenRTB.
 	TransactionID     string          `json:"tid,omitempty"`    // Transaction ID that must be common across all participants in this bid request (e.g., potentially multiple exchanges).
 	PaymentChain      string          `json:"pchain,omitempty"` // Payment ID chain string containing embedded syntax described in the TAG Payment ID Protocol v1.0.
+	SChain            *SupplyChain    `json:"schain,omitempty"` // Supply chain of the entities involved in the direct flow of payment for the inventory.
 	FinalSaleDecision int8            `json:"fd"`               // Entity responsible for the final impression sale decision, where 0 = exchange, 1 = upstream source.
 }
+
+// SupplyChain object represents the chain of entities involved in the direct flow of payment
+// for inventory, as defined by the IAB Tech Lab SupplyChain specification.
+type SupplyChain struct {
+	Nodes    []SupplyChainNode `json:"nodes"`         // Array of nodes in the order the chain was formed.
+	Ext      json.RawMessage   `json:"ext,omitempty"` // -
+	Version  string            `json:"ver"`           // Version of the supply chain specification in use, e.g. "1.0".
+	Complete int8              `json:"complete"`      // Flag indicating whether the chain contains all nodes up to the owner of the site, app or other medium.
+}
+
+// SupplyChainNode object identifies a specific entity participating in the supply chain.
+type SupplyChainNode struct {
+	Ext       json.RawMessage `json:"ext,omitempty"`    // -
+	ASI       string          `json:"asi"`              // Canonical domain name of the SSP, exchange, header wrapper, etc. system.
+	SID       string          `json:"sid"`              // Identifier associated with the seller or reseller account within the advertising system.
+	RequestID string          `json:"rid,omitempty"`    // OpenRTB request ID of the request as issued by this seller.
+	Name      string          `json:"name,omitempty"`   // Name of the company paid for inventory transacted under the given SID.
+	Domain    string          `json:"domain,omitempty"` // Business domain name of the entity represented by this node.
+	HP        int8            `json:"hp"`               // Indicates whether this node will be involved in the flow of payment for the inventory.
+}
@@ -1,7 +1,30 @@
 package openrtb
 
-import "github.com/goccy/go-json"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 
+	"github.com/goccy/go-json"
+)
+
+// ErrInvalidClientHints is returned when a User-Agent Client Hints header is malformed.
+var ErrInvalidClientHints = errors.New("openrtb: invalid client hints")
+
+// User-Agent Client Hints request headers.
+const (
+	HeaderSecCHUA                = "Sec-CH-UA"
+	HeaderSecCHUAFullVersionList = "Sec-CH-UA-Full-Version-List"
+	HeaderSecCHUAPlatform        = "Sec-CH-UA-Platform"
+	HeaderSecCHUAPlatformVersion = "Sec-CH-UA-Platform-Version"
+	HeaderSecCHUAMobile          = "Sec-CH-UA-Mobile"
+	HeaderSecCHUAArch            = "Sec-CH-UA-Arch"
+	HeaderSecCHUABitness         = "Sec-CH-UA-Bitness"
+	HeaderSecCHUAModel           = "Sec-CH-UA-Model"
+)
+
+// UserAgent is a structured user agent, populated from User-Agent Client Hints.
 type UserAgent struct {
 	Browsers     []BrandVersion  `json:"browsers,omitempty"`     // A browser or similar software component
 	Platform     BrandVersion    `json:"platform,omitempty"`     // The user agent’s execution platform / OS
@@ -9,12 +32,140 @@ type UserAgent struct {
 	Architecture string          `json:"architecture,omitempty"` // Device’s major binary architecture, e.g. "x86" or "arm". Taken from the Sec-CH-UA-Arch header
 	Bitness      string          `json:"bitness,omitempty"`      // Device’s bitness, e.g. "64" for 64-bit architecture. Taken from the Sec-CH-UA-Bitness header
 	Model        string          `json:"model,omitempty"`        // Device model. Taken from the Sec-CH-UAModel header
-	Source       int             `json:"source,omitempty"`       // The source of data used to create this object, List: User-Agent Source in AdCOM 1.0
+	Source       int             `json:"source,omitempty"`       // The source of data used to create this object, see UASource
 	Ext          json.RawMessage `json:"ext,omitempty"`
 }
 
+// BrandVersion identifies a user agent component, such as a browser or platform.
 type BrandVersion struct {
 	Brand   string          `json:"brand,omitempty"`   // A brand identifier, for example, "Chrome" or "Windows". Taken from the Sec-CH-UA-Full-Version or Sec-CH-UA-Platform header
 	Version []string        `json:"version,omitempty"` // A sequence of version components, in descending hierarchical order (major, minor, patch)
 	Ext     json.RawMessage `json:"ext,omitempty"`
 }
+
+// NewUserAgent builds a UserAgent from the User-Agent Client Hints of an HTTP
+// request. GREASE brands are omitted. Source is set to UASourceHighEntropy if
+// any high-entropy hint is present and to UASourceLowEntropy otherwise.
+// Returns nil if h contains no client hints.
+func NewUserAgent(h http.Header) (*UserAgent, error) {
+	ua := new(UserAgent)
+	low, high := false, false
+
+	if vals := h.Values(HeaderSecCHUAFullVersionList); len(vals) != 0 {
+		browsers, err := parseBrandList(HeaderSecCHUAFullVersionList, vals)
+		if err != nil {
+			return nil, err
+		}
+		ua.Browsers, high = browsers, true
+	} else if vals := h.Values(HeaderSecCHUA); len(vals) != 0 {
+		browsers, err := parseBrandList(HeaderSecCHUA, vals)
+		if err != nil {
+			return nil, err
+		}
+		ua.Browsers, low = browsers, true
+	}
+
+	if s, ok, err := parseCHString(h, HeaderSecCHUAPlatform); err != nil {
+		return nil, err
+	} else if ok {
+		ua.Platform.Brand, low = s, true
+	}
+	if s, ok, err := parseCHString(h, HeaderSecCHUAPlatformVersion); err != nil {
+		return nil, err
+	} else if ok {
+		ua.Platform.Version, high = splitVersion(s), true
+	}
+
+	if v := h.Get(HeaderSecCHUAMobile); v != "" {
+		item, err := parseSFItem(v)
+		if err != nil || (item.Value != "?0" && item.Value != "?1") {
+			return nil, chError(HeaderSecCHUAMobile)
+		}
+		if item.Value == "?1" {
+			ua.Mobile = 1
+		}
+		low = true
+	}
+
+	for _, f := range []struct {
+		header string
+		dst    *string
+	}{
+		{HeaderSecCHUAArch, &ua.Architecture},
+		{HeaderSecCHUABitness, &ua.Bitness},
+		{HeaderSecCHUAModel, &ua.Model},
+	} {
+		s, ok, err := parseCHString(h, f.header)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*f.dst, high = s, true
+		}
+	}
+
+	switch {
+	case high:
+		ua.Source = int(UASourceHighEntropy)
+	case low:
+		ua.Source = int(UASourceLowEntropy)
+	default:
+		return nil, nil
+	}
+	return ua, nil
+}
+
+// IsGREASEBrand returns true if brand is a GREASE value such as
+// "Not=A?Brand", which browsers add to brand lists to prevent ossification.
+func IsGREASEBrand(brand string) bool {
+	stripped := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(" ():-./;=?_", r) {
+			return -1
+		}
+		return r
+	}, brand)
+	return strings.EqualFold(stripped, "NotABrand")
+}
+
+func parseBrandList(header string, vals []string) ([]BrandVersion, error) {
+	items, err := parseSFList(strings.Join(vals, ", "))
+	if err != nil {
+		return nil, chError(header)
+	}
+
+	brands := make([]BrandVersion, 0, len(items))
+	for _, item := range items {
+		if item.Value == "" || IsGREASEBrand(item.Value) {
+			continue
+		}
+		brands = append(brands, BrandVersion{Brand: item.Value, Version: splitVersion(item.Params["v"])})
+	}
+	if len(brands) == 0 {
+		return nil, nil
+	}
+	return brands, nil
+}
+
+func parseCHString(h http.Header, key string) (string, bool, error) {
+	v := h.Get(key)
+	if v == "" {
+		return "", false, nil
+	}
+
+	item, err := parseSFItem(v)
+	if err != nil {
+		return "", false, chError(key)
+	}
+	return item.Value, true, nil
+}
+
+func splitVersion(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ".")
+}
+
+func chError(header string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidClientHints, header)
+}
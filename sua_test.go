@@ -0,0 +1,114 @@
+package openrtb_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestNewUserAgent(t *testing.T) {
+	h := make(http.Header)
+	h.Set("Sec-CH-UA", `"Chromium";v="118", "Google Chrome";v="118", "Not=A?Brand";v="99"`)
+	h.Set("Sec-CH-UA-Full-Version-List", `"Chromium";v="118.0.5993.88", "Google Chrome";v="118.0.5993.88", "Not=A?Brand";v="99.0.0.0"`)
+	h.Set("Sec-CH-UA-Platform", `"Windows"`)
+	h.Set("Sec-CH-UA-Platform-Version", `"15.0.0"`)
+	h.Set("Sec-CH-UA-Mobile", `?0`)
+	h.Set("Sec-CH-UA-Arch", `"x86"`)
+	h.Set("Sec-CH-UA-Bitness", `"64"`)
+	h.Set("Sec-CH-UA-Model", `""`)
+
+	got, err := NewUserAgent(h)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := &UserAgent{
+		Browsers: []BrandVersion{
+			{Brand: "Chromium", Version: []string{"118", "0", "5993", "88"}},
+			{Brand: "Google Chrome", Version: []string{"118", "0", "5993", "88"}},
+		},
+		Platform:     BrandVersion{Brand: "Windows", Version: []string{"15", "0", "0"}},
+		Architecture: "x86",
+		Bitness:      "64",
+		Source:       int(UASourceHighEntropy),
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+}
+
+func TestNewUserAgent_lowEntropy(t *testing.T) {
+	h := make(http.Header)
+	h.Add("Sec-CH-UA", `" Not A;Brand";v="99", "Chromium";v="96"`)
+	h.Add("Sec-CH-UA", `"Google Chrome"; v="96";extra`)
+	h.Set("Sec-CH-UA-Platform", `"Android"`)
+	h.Set("Sec-CH-UA-Mobile", `?1`)
+
+	got, err := NewUserAgent(h)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := &UserAgent{
+		Browsers: []BrandVersion{
+			{Brand: "Chromium", Version: []string{"96"}},
+			{Brand: "Google Chrome", Version: []string{"96"}},
+		},
+		Platform: BrandVersion{Brand: "Android"},
+		Mobile:   1,
+		Source:   int(UASourceLowEntropy),
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+}
+
+func TestNewUserAgent_none(t *testing.T) {
+	h := make(http.Header)
+	h.Set("User-Agent", "Mozilla/5.0")
+
+	got, err := NewUserAgent(h)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected nil, got %+v", got)
+	}
+}
+
+func TestNewUserAgent_invalid(t *testing.T) {
+	for header, value := range map[string]string{
+		"Sec-CH-UA":                   `"Chromium";v="118",`,
+		"Sec-CH-UA-Full-Version-List": `"Chromium;v="118"`,
+		"Sec-CH-UA-Platform":          `"Windows" "Linux"`,
+		"Sec-CH-UA-Mobile":            `1`,
+		"Sec-CH-UA-Model":             `"Pixel \7"`,
+	} {
+		h := make(http.Header)
+		h.Set(header, value)
+		if _, err := NewUserAgent(h); !errors.Is(err, ErrInvalidClientHints) {
+			t.Errorf("%s: expected %v, got %v", header, ErrInvalidClientHints, err)
+		}
+	}
+}
+
+func TestIsGREASEBrand(t *testing.T) {
+	for brand, exp := range map[string]bool{
+		"Not=A?Brand":   true,
+		" Not A;Brand":  true,
+		"Not_A Brand":   true,
+		"Not)A;Brand":   true,
+		"Not/A)Brand":   true,
+		"Not.A/Brand":   true,
+		"Chromium":      false,
+		"Google Chrome": false,
+		"Not a Browser": false,
+	} {
+		if got := IsGREASEBrand(brand); exp != got {
+			t.Errorf("%q: expected %v, got %v", brand, exp, got)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package targeting
+
+import "reflect"
+
+type node interface {
+	eval(v reflect.Value) bool
+}
+
+type constNode bool
+
+func (n constNode) eval(reflect.Value) bool { return bool(n) }
+
+type notNode struct{ n node }
+
+func (n notNode) eval(v reflect.Value) bool { return !n.n.eval(v) }
+
+type andNode []node
+
+func (n andNode) eval(v reflect.Value) bool {
+	for _, c := range n {
+		if !c.eval(v) {
+			return false
+		}
+	}
+	return true
+}
+
+type orNode []node
+
+func (n orNode) eval(v reflect.Value) bool {
+	for _, c := range n {
+		if c.eval(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// cmpNode is true if any value reachable by path satisfies pred.
+type cmpNode struct {
+	path []step
+	pred func(reflect.Value) bool
+}
+
+func (n *cmpNode) eval(v reflect.Value) bool {
+	return walk(v, n.path, n.pred)
+}
+
+type stepKind int8
+
+const (
+	stepField  stepKind = iota // select field by index
+	stepIter                   // visit all elements
+	stepIndex                  // select element n
+	stepFilter                 // visit elements matching filter
+)
+
+type step struct {
+	kind   stepKind
+	index  []int
+	n      int
+	filter node
+}
+
+// walk visits the values reachable from v by steps and returns true as soon
+// as fn does. Nil pointers end the traversal.
+func walk(v reflect.Value, steps []step, fn func(reflect.Value) bool) bool {
+	v, ok := indirect(v)
+	if !ok {
+		return false
+	}
+	if len(steps) == 0 {
+		return fn(v)
+	}
+
+	s, rest := &steps[0], steps[1:]
+	switch s.kind {
+	case stepField:
+		for _, i := range s.index {
+			v = v.Field(i)
+		}
+		return walk(v, rest, fn)
+	case stepIter:
+		for i := 0; i < v.Len(); i++ {
+			if walk(v.Index(i), rest, fn) {
+				return true
+			}
+		}
+	case stepIndex:
+		if s.n < v.Len() {
+			return walk(v.Index(s.n), rest, fn)
+		}
+	case stepFilter:
+		for i := 0; i < v.Len(); i++ {
+			if elem, ok := indirect(v.Index(i)); ok && s.filter.eval(elem) && walk(elem, rest, fn) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// indirect dereferences pointers. Returns false for nil pointers.
+func indirect(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// exists is true for non-zero values and non-empty arrays.
+func exists(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() != 0
+	}
+	return !v.IsZero()
+}
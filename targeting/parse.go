@@ -0,0 +1,401 @@
+package targeting
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tomlightning/openrtb/v3/internal/jsonfields"
+)
+
+type parser struct {
+	toks []token
+	i    int
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+func (p *parser) next() token { t := p.toks[p.i]; p.i = min(p.i+1, len(p.toks)-1); return t }
+
+// accept consumes the next token if it is one of the given punctuations or
+// keywords.
+func (p *parser) accept(texts ...string) (token, bool) {
+	tok := p.peek()
+	if tok.kind != tokPunct && tok.kind != tokIdent {
+		return tok, false
+	}
+	for _, s := range texts {
+		if tok.text == s {
+			return p.next(), true
+		}
+	}
+	return tok, false
+}
+
+func (p *parser) expect(text string) error {
+	if tok, ok := p.accept(text); !ok {
+		return syntaxError(tok.pos, fmt.Sprintf("expected %q, got %s", text, tok))
+	}
+	return nil
+}
+
+func (p *parser) parseOr(t reflect.Type) (node, error) {
+	n, err := p.parseAnd(t)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := orNode{n}
+	for {
+		if _, ok := p.accept("||", "or"); !ok {
+			break
+		}
+		if n, err = p.parseAnd(t); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+func (p *parser) parseAnd(t reflect.Type) (node, error) {
+	n, err := p.parseNot(t)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := andNode{n}
+	for {
+		if _, ok := p.accept("&&", "and"); !ok {
+			break
+		}
+		if n, err = p.parseNot(t); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+func (p *parser) parseNot(t reflect.Type) (node, error) {
+	if _, ok := p.accept("!", "not"); ok {
+		n, err := p.parseNot(t)
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	}
+	return p.parseTerm(t)
+}
+
+func (p *parser) parseTerm(t reflect.Type) (node, error) {
+	if _, ok := p.accept("("); ok {
+		n, err := p.parseOr(t)
+		if err != nil {
+			return nil, err
+		}
+		return n, p.expect(")")
+	}
+	if tok, ok := p.accept("true", "false"); ok {
+		return constNode(tok.text == "true"), nil
+	}
+
+	path, typ, err := p.parsePath(t)
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.next()
+	if op.kind != tokPunct && op.kind != tokIdent {
+		return nil, syntaxError(op.pos, "expected operator, got "+op.String())
+	}
+
+	switch op.text {
+	case "exists":
+		return &cmpNode{path: path, pred: exists}, nil
+	case "contains":
+		lit, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		if isArray(typ) {
+			path, typ = append(path, step{kind: stepIter}), typ.Elem()
+			op.text = "=="
+		} else if deref(typ).Kind() != reflect.String {
+			return nil, fmt.Errorf("%w at offset %d: contains requires a string or array", ErrType, op.pos)
+		}
+		pred, err := predicate(op, []literal{lit}, typ)
+		return &cmpNode{path: path, pred: pred}, err
+	case "in":
+		lits, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		if isArray(typ) {
+			path, typ = append(path, step{kind: stepIter}), typ.Elem()
+		}
+		pred, err := predicate(op, lits, typ)
+		return &cmpNode{path: path, pred: pred}, err
+	case "==", "=", "!=", "<", "<=", ">", ">=":
+		lit, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		if isArray(typ) {
+			path, typ = append(path, step{kind: stepIter}), typ.Elem()
+		}
+		pred, err := predicate(op, []literal{lit}, typ)
+		return &cmpNode{path: path, pred: pred}, err
+	}
+	return nil, syntaxError(op.pos, "expected operator, got "+op.String())
+}
+
+// parsePath parses a path relative to the struct type t and returns its
+// steps and the type it resolves to.
+func (p *parser) parsePath(t reflect.Type) ([]step, reflect.Type, error) {
+	tok := p.next()
+	if tok.kind != tokIdent {
+		return nil, nil, syntaxError(tok.pos, "expected field, got "+tok.String())
+	}
+
+	var steps []step
+	name := tok.text
+	for {
+		t = deref(t)
+		if isArray(t) {
+			steps, t = append(steps, step{kind: stepIter}), deref(t.Elem())
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, nil, fmt.Errorf("%w at offset %d: %q is not an object", ErrUnknownField, tok.pos, name)
+		}
+		field, ok := jsonfields.Of(t)[tok.text]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w at offset %d: %q has no field %q", ErrUnknownField, tok.pos, t.Name(), tok.text)
+		}
+		steps, t = append(steps, step{kind: stepField, index: field.Index}), field.Type
+
+		for {
+			if _, ok := p.accept("["); !ok {
+				break
+			}
+			if !isArray(deref(t)) {
+				return nil, nil, fmt.Errorf("%w at offset %d: %q is not an array", ErrType, tok.pos, name)
+			}
+			t = deref(t)
+
+			if num := p.peek(); num.kind == tokNumber {
+				p.next()
+				n, err := strconv.Atoi(num.text)
+				if err != nil || n < 0 {
+					return nil, nil, syntaxError(num.pos, "invalid index "+num.text)
+				}
+				steps = append(steps, step{kind: stepIndex, n: n})
+			} else {
+				elem := deref(t.Elem())
+				if elem.Kind() != reflect.Struct {
+					return nil, nil, fmt.Errorf("%w at offset %d: %q is not an array of objects", ErrType, num.pos, name)
+				}
+				cond, err := p.parseOr(elem)
+				if err != nil {
+					return nil, nil, err
+				}
+				steps = append(steps, step{kind: stepFilter, filter: cond})
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, nil, err
+			}
+			t = t.Elem()
+		}
+
+		if _, ok := p.accept("."); !ok {
+			return steps, t, nil
+		}
+		if tok = p.next(); tok.kind != tokIdent {
+			return nil, nil, syntaxError(tok.pos, "expected field, got "+tok.String())
+		}
+		name += "." + tok.text
+	}
+}
+
+type literal struct {
+	tok  token
+	num  float64
+	str  string
+	bool bool
+}
+
+func (p *parser) parseLiteral() (literal, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return literal{}, syntaxError(tok.pos, "invalid number "+tok.text)
+		}
+		return literal{tok: tok, num: f}, nil
+	case tokString:
+		return literal{tok: tok, str: tok.text}, nil
+	case tokIdent:
+		if tok.text == "true" || tok.text == "false" {
+			return literal{tok: tok, bool: tok.text == "true"}, nil
+		}
+	}
+	return literal{}, syntaxError(tok.pos, "expected literal, got "+tok.String())
+}
+
+func (p *parser) parseList() ([]literal, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+
+	var lits []literal
+	for {
+		lit, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		lits = append(lits, lit)
+
+		if _, ok := p.accept(","); !ok {
+			break
+		}
+	}
+	return lits, p.expect("]")
+}
+
+// predicate compiles a comparison of values of type t with literals.
+func predicate(op token, lits []literal, t reflect.Type) (func(reflect.Value) bool, error) {
+	typeError := func(lit literal, want string) error {
+		return fmt.Errorf("%w at offset %d: expected %s, got %s", ErrType, lit.tok.pos, want, lit.tok)
+	}
+	t = deref(t)
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		nums := make([]float64, len(lits))
+		for i, lit := range lits {
+			if lit.tok.kind != tokNumber {
+				return nil, typeError(lit, "number")
+			}
+			nums[i] = lit.num
+		}
+
+		get := func(v reflect.Value) float64 { return v.Float() }
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			get = func(v reflect.Value) float64 { return float64(v.Int()) }
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			get = func(v reflect.Value) float64 { return float64(v.Uint()) }
+		case reflect.Float32:
+			// compare at the precision of the field
+			for i := range nums {
+				nums[i] = float64(float32(nums[i]))
+			}
+		}
+		if op.text == "in" {
+			return func(v reflect.Value) bool {
+				n := get(v)
+				for _, x := range nums {
+					if n == x {
+						return true
+					}
+				}
+				return false
+			}, nil
+		}
+		cmp := comparator(op.text, func(a, b float64) int { return compare(a, b) })
+		x := nums[0]
+		if math.IsNaN(x) {
+			return nil, typeError(lits[0], "number")
+		}
+		return func(v reflect.Value) bool { return cmp(get(v), x) }, nil
+
+	case reflect.String:
+		strs := make([]string, len(lits))
+		for i, lit := range lits {
+			if lit.tok.kind != tokString {
+				return nil, typeError(lit, "string")
+			}
+			strs[i] = lit.str
+		}
+
+		switch op.text {
+		case "in":
+			return func(v reflect.Value) bool {
+				s := v.String()
+				for _, x := range strs {
+					if s == x {
+						return true
+					}
+				}
+				return false
+			}, nil
+		case "contains":
+			x := strs[0]
+			return func(v reflect.Value) bool { return strings.Contains(v.String(), x) }, nil
+		}
+		cmp := comparator(op.text, strings.Compare)
+		x := strs[0]
+		return func(v reflect.Value) bool { return cmp(v.String(), x) }, nil
+
+	case reflect.Bool:
+		if op.text != "==" && op.text != "=" && op.text != "!=" {
+			return nil, fmt.Errorf("%w at offset %d: %s is not supported for booleans", ErrType, op.pos, op)
+		}
+		lit := lits[0]
+		if lit.tok.kind != tokIdent {
+			return nil, typeError(lit, "boolean")
+		}
+		eq := op.text != "!="
+		return func(v reflect.Value) bool { return (v.Bool() == lit.bool) == eq }, nil
+	}
+	return nil, fmt.Errorf("%w at offset %d: values of type %s cannot be compared", ErrType, op.pos, t)
+}
+
+func comparator[T any](op string, cmp func(a, b T) int) func(a, b T) bool {
+	switch op {
+	case "!=":
+		return func(a, b T) bool { return cmp(a, b) != 0 }
+	case "<":
+		return func(a, b T) bool { return cmp(a, b) < 0 }
+	case "<=":
+		return func(a, b T) bool { return cmp(a, b) <= 0 }
+	case ">":
+		return func(a, b T) bool { return cmp(a, b) > 0 }
+	case ">=":
+		return func(a, b T) bool { return cmp(a, b) >= 0 }
+	}
+	return func(a, b T) bool { return cmp(a, b) == 0 }
+}
+
+func compare(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isArray returns true for slices and arrays other than raw JSON and byte
+// strings.
+func isArray(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() != reflect.Uint8
+}
@@ -0,0 +1,107 @@
+// Package targeting evaluates declarative targeting rules against bid
+// requests.
+//
+// Rules are written in a small expression language which references request
+// attributes by their JSON paths:
+//
+//	device.devicetype in [4, 5] && site.cat contains "IAB19"
+//	imp.video.plcmt == 1 || imp.banner.format[w=300 && h=250] exists
+//	not (device.geo.country in ["USA", "CAN"])
+//
+// Supported operators are ==, !=, <, <=, >, >=, in, contains, exists and the
+// logical operators &&, || and ! (or their keywords and, or, not). Inside
+// brackets, = may be used for ==.
+//
+// Paths traverse arrays implicitly: a comparison is true if any value
+// reachable by the path satisfies it, e.g. imp.video.plcmt == 1 matches
+// requests with at least one such impression. Array elements can be selected
+// by index, imp[0], or by a condition relative to the element,
+// imp.pmp.deals[id="deal-1"]. The contains operator tests strings for
+// substrings and arrays for elements.
+//
+// Expressions are compiled against the BidRequest schema, so unknown paths and
+// mismatched literals are reported by Compile. Compiled expressions are
+// immutable and safe for concurrent use.
+package targeting
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/tomlightning/openrtb/v3"
+)
+
+// Compilation errors.
+var (
+	ErrSyntax       = errors.New("targeting: syntax error")
+	ErrUnknownField = errors.New("targeting: unknown field")
+	ErrType         = errors.New("targeting: type mismatch")
+)
+
+func syntaxError(pos int, msg string) error {
+	return fmt.Errorf("%w at offset %d: %s", ErrSyntax, pos, msg)
+}
+
+// Expr is a compiled targeting expression.
+type Expr struct {
+	src  string
+	root node
+}
+
+var bidRequestType = reflect.TypeOf(openrtb.BidRequest{})
+
+// Compile parses an expression and checks it against the BidRequest schema.
+func Compile(src string) (*Expr, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{toks: toks}
+	root, err := p.parseOr(bidRequestType)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, syntaxError(tok.pos, "unexpected "+tok.String())
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+// MustCompile is like Compile but panics on errors.
+func MustCompile(src string) *Expr {
+	e, err := Compile(src)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Eval returns true if req satisfies the expression.
+func (e *Expr) Eval(req *openrtb.BidRequest) bool {
+	if req == nil {
+		return false
+	}
+	return e.root.eval(reflect.ValueOf(req).Elem())
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (e *Expr) MarshalText() ([]byte, error) {
+	return []byte(e.src), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (e *Expr) UnmarshalText(text []byte) error {
+	x, err := Compile(string(text))
+	if err != nil {
+		return err
+	}
+	*e = *x
+	return nil
+}
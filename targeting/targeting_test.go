@@ -0,0 +1,169 @@
+package targeting_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/tomlightning/openrtb/v3"
+
+	. "github.com/tomlightning/openrtb/v3/targeting"
+)
+
+func fixture(t testing.TB, fname string) *openrtb.BidRequest {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("..", "testdata", fname+".json"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var req *openrtb.BidRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return req
+}
+
+func TestExpr_Eval(t *testing.T) {
+	req := fixture(t, "breq.video")
+	req.Device.DeviceType = openrtb.DeviceTypePhone
+	req.Device.Geo = &openrtb.Geo{Country: "USA", Latitude: 40.7}
+	req.Impressions[1].Video.Plcmt = openrtb.VideoPlcmtInstream
+
+	for src, exp := range map[string]bool{
+		`true`:                                                              true,
+		`device.devicetype in [4, 5]`:                                       true,
+		`device.devicetype in [1, 2]`:                                       false,
+		`device.devicetype == 4`:                                            true,
+		`device.devicetype != 4`:                                            false,
+		`site.cat contains "IAB2-1"`:                                        true,
+		`site.cat contains "IAB19"`:                                         false,
+		`site.content.cat in ["IAB2-2", "IAB3"]`:                            true,
+		`site.domain contains "abcd"`:                                       true,
+		`site.domain == "siteabcd.com"`:                                     true,
+		`site.domain > "site"`:                                              true,
+		`imp.video.plcmt == 1`:                                              true,
+		`imp[0].video.plcmt == 1`:                                           false,
+		`imp[1].video.plcmt == 1`:                                           true,
+		`imp[9].video.plcmt == 1`:                                           false,
+		`imp.video.maxduration >= 60`:                                       true,
+		`imp.video.maxduration > 60`:                                        false,
+		`imp.video.protocols contains 3`:                                    true,
+		`imp.video.startdelay < 0`:                                          true,
+		`imp.bidfloor > 1.5`:                                                true,
+		`imp.pmp.deals[id="1452f.eadb4.f9bc"] exists`:                       true,
+		`imp.pmp.deals[id="unknown"] exists`:                                false,
+		`imp.pmp.deals[bidfloor > 3 && id="1452f.eadb4.7aaa"] exists`:       true,
+		`imp.pmp.deals[bidfloor < 3].bidfloor == 2.5`:                       true,
+		`imp[0].pmp.deals[bidfloor < 3] exists`:                             false,
+		`imp.pmp.deals.ext exists`:                                          true,
+		`device.geo.lat > 40.5 and device.geo.country == "USA"`:             true,
+		`device.geo.country in ["USA"] && not (site.cat contains "IAB2-1")`: false,
+		`!(app exists) && site exists`:                                      true,
+		`app.bundle == "x" || user.data[name="Data Provider 1"] exists`:     true,
+		`app.bundle == ""`:                                                  false,
+		`regs.coppa == 0`:                                                   false,
+		`user.data.segment exists`:                                          true,
+		`device.lmt == 0 && device.dnt == 0`:                                true,
+		`imp.banner.format[w=300 && h=250] exists`:                          false,
+		`badv exists`:                                                       false,
+	} {
+		e, err := Compile(src)
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", src, err)
+			continue
+		}
+		if got := e.Eval(req); exp != got {
+			t.Errorf("%s: expected %v, got %v", src, exp, got)
+		}
+	}
+}
+
+func TestCompile_errors(t *testing.T) {
+	for src, exp := range map[string]error{
+		``:                                ErrSyntax,
+		`device.devicetype in 4`:          ErrSyntax,
+		`device.devicetype ==`:            ErrSyntax,
+		`device.devicetype == 4 4`:        ErrSyntax,
+		`(device.devicetype == 4`:         ErrSyntax,
+		`site.name == "unterminated`:      ErrSyntax,
+		`device.devicetype # 4`:           ErrSyntax,
+		`imp[-1].id == "1"`:               ErrSyntax,
+		`device.unknown == 4`:             ErrUnknownField,
+		`device.ua.length > 3`:            ErrUnknownField,
+		`imp.ext.foo == 1`:                ErrUnknownField,
+		`device.devicetype == "4"`:        ErrType,
+		`site.domain == 4`:                ErrType,
+		`site.domain in ["a", 1]`:         ErrType,
+		`device.devicetype contains 4`:    ErrType,
+		`device[0].ua == "x"`:             ErrType,
+		`site.cat[id="x"] exists`:         ErrType,
+		`device.geo < 4`:                  ErrType,
+		`imp.pmp.deals[unknown=1] exists`: ErrUnknownField,
+	} {
+		if _, err := Compile(src); !errors.Is(err, exp) {
+			t.Errorf("%q: expected %v, got %v", src, exp, err)
+		}
+	}
+}
+
+func TestExpr_UnmarshalText(t *testing.T) {
+	var rule struct {
+		Name string `json:"name"`
+		When *Expr  `json:"when"`
+	}
+	if err := json.Unmarshal([]byte(`{"name":"phones","when":"device.devicetype in [1, 4]"}`), &rule); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := "device.devicetype in [1, 4]", rule.When.String(); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if !rule.When.Eval(&openrtb.BidRequest{Device: &openrtb.Device{DeviceType: openrtb.DeviceTypeMobile}}) {
+		t.Error("expected rule to match")
+	}
+
+	if err := json.Unmarshal([]byte(`{"when":"device.unknown"}`), &rule); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("expected %v, got %v", ErrUnknownField, err)
+	}
+}
+
+func TestExpr_Eval_concurrent(t *testing.T) {
+	req := fixture(t, "breq.video")
+	subject := MustCompile(`imp.pmp.deals[bidfloor < 3] exists && site.cat contains "IAB2-2"`)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if !subject.Eval(req) {
+					t.Error("expected rule to match")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if subject.Eval(nil) {
+		t.Error("expected nil request not to match")
+	}
+}
+
+func BenchmarkExpr_Eval(b *testing.B) {
+	req := fixture(b, "breq.video")
+	subject := MustCompile(`device.devicetype in [4, 5] || (site.cat contains "IAB2-2" && imp.pmp.deals[bidfloor > 3] exists)`)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !subject.Eval(req) {
+			b.Fatal("expected rule to match")
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Package taxonomy provides the IAB Tech Lab category taxonomies which are
+// referenced by the cattax attribute of OpenRTB 2.6.
+//
+// Tables are read from the tab-separated files published by the IAB Tech Lab,
+// identified by their "Unique ID", "Parent" and "Name" columns. The Content
+// Taxonomy 1.0 table is embedded, the tables of Content Taxonomy 2.x/3.0, Ad
+// Product Taxonomy 1.0/2.0 and Audience Taxonomy 1.1 are not embedded yet and
+// must be loaded from the official releases via Parse.
+package taxonomy
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidTable is returned when a taxonomy table cannot be parsed.
+var ErrInvalidTable = errors.New("taxonomy: invalid table")
+
+//go:embed content1.tsv
+var content1TSV []byte
+
+var content1 = sync.OnceValue(func() *Taxonomy {
+	t, err := Parse("IAB Content Taxonomy 1.0", bytes.NewReader(content1TSV))
+	if err != nil {
+		panic(err)
+	}
+	return t
+})
+
+// Content1 returns the embedded IAB Content Taxonomy 1.0.
+func Content1() *Taxonomy { return content1() }
+
+// Category is a single node of a taxonomy.
+type Category struct {
+	ID     string // Unique ID, e.g. "IAB1-1" or "JLBCU7"
+	Parent string // Unique ID of the parent, empty for tier 1 categories
+	Name   string // Name of the category
+	Tier   int    // Tier of the category, starting at 1
+}
+
+// Taxonomy is a tree of categories.
+type Taxonomy struct {
+	Name string
+
+	categories map[string]Category
+	children   map[string][]string
+	order      []string
+}
+
+// Parse reads a taxonomy table. The first row which contains a "Unique ID"
+// column is used as the header, preceding rows are ignored. Further columns,
+// such as the tier names of the official releases, are ignored too.
+func Parse(name string, r io.Reader) (*Taxonomy, error) {
+	t := &Taxonomy{
+		Name:       name,
+		categories: make(map[string]Category),
+		children:   make(map[string][]string),
+	}
+
+	idCol, parentCol, nameCol := -1, -1, -1
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		cols := strings.Split(strings.TrimRight(scanner.Text(), "\r"), "\t")
+
+		if idCol < 0 {
+			for i, col := range cols {
+				switch col = strings.TrimSpace(col); {
+				case strings.HasPrefix(col, "Unique ID"):
+					idCol = i
+				case strings.HasPrefix(col, "Parent") && parentCol < 0:
+					parentCol = i
+				case (col == "Name" || strings.HasPrefix(col, "Condensed Name")) && nameCol < 0:
+					nameCol = i
+				}
+			}
+			if idCol >= 0 && (parentCol < 0 || nameCol < 0) {
+				return nil, fmt.Errorf("%w: line %d: missing parent or name column", ErrInvalidTable, line)
+			}
+			continue
+		}
+
+		id := column(cols, idCol)
+		if id == "" {
+			continue
+		}
+		if _, ok := t.categories[id]; ok {
+			return nil, fmt.Errorf("%w: line %d: duplicate ID %q", ErrInvalidTable, line, id)
+		}
+
+		parent := column(cols, parentCol)
+		t.categories[id] = Category{ID: id, Parent: parent, Name: column(cols, nameCol)}
+		t.order = append(t.order, id)
+		if parent != "" {
+			t.children[parent] = append(t.children[parent], id)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if idCol < 0 {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidTable)
+	}
+
+	for _, id := range t.order {
+		if err := t.assignTier(id); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func column(cols []string, i int) string {
+	if i < len(cols) {
+		return strings.TrimSpace(cols[i])
+	}
+	return ""
+}
+
+func (t *Taxonomy) assignTier(id string) error {
+	c := t.categories[id]
+	if c.Tier != 0 {
+		return nil
+	}
+
+	tier := 1
+	for parent := c.Parent; parent != ""; tier++ {
+		p, ok := t.categories[parent]
+		if !ok {
+			return fmt.Errorf("%w: unknown parent %q of %q", ErrInvalidTable, parent, id)
+		}
+		if tier > len(t.categories) {
+			return fmt.Errorf("%w: cyclic parent of %q", ErrInvalidTable, id)
+		}
+		parent = p.Parent
+	}
+
+	c.Tier = tier
+	t.categories[id] = c
+	return nil
+}
+
+// Len returns the number of categories.
+func (t *Taxonomy) Len() int { return len(t.order) }
+
+// Contains returns true if the taxonomy contains the category ID.
+func (t *Taxonomy) Contains(id string) bool {
+	_, ok := t.categories[id]
+	return ok
+}
+
+// Lookup returns the category with the given ID.
+func (t *Taxonomy) Lookup(id string) (Category, bool) {
+	c, ok := t.categories[id]
+	return c, ok
+}
+
+// Parent returns the parent of a category.
+func (t *Taxonomy) Parent(id string) (Category, bool) {
+	c, ok := t.categories[id]
+	if !ok || c.Parent == "" {
+		return Category{}, false
+	}
+	return t.Lookup(c.Parent)
+}
+
+// Children returns the direct children of a category, in table order.
+func (t *Taxonomy) Children(id string) []Category {
+	ids := t.children[id]
+	if len(ids) == 0 {
+		return nil
+	}
+
+	res := make([]Category, 0, len(ids))
+	for _, child := range ids {
+		res = append(res, t.categories[child])
+	}
+	return res
+}
+
+// Within returns true if the category is the ancestor or one of its
+// descendants.
+func (t *Taxonomy) Within(id, ancestor string) bool {
+	if !t.Contains(id) {
+		return false
+	}
+	for ; id != ""; id = t.categories[id].Parent {
+		if id == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// Categories returns all categories, in table order.
+func (t *Taxonomy) Categories() []Category {
+	res := make([]Category, 0, len(t.order))
+	for _, id := range t.order {
+		res = append(res, t.categories[id])
+	}
+	return res
+}
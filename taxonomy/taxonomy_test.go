@@ -0,0 +1,118 @@
+package taxonomy_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/tomlightning/openrtb/v3/taxonomy"
+)
+
+func TestContent1(t *testing.T) {
+	subject := Content1()
+	if exp, got := 392, subject.Len(); exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	if exp, got := (Category{ID: "IAB1", Name: "Arts & Entertainment", Tier: 1}), mustLookup(t, subject, "IAB1"); exp != got {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if exp, got := (Category{ID: "IAB17-44", Parent: "IAB17", Name: "World Soccer", Tier: 2}), mustLookup(t, subject, "IAB17-44"); exp != got {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if subject.Contains("IAB27") || subject.Contains("iab1") {
+		t.Errorf("expected unknown categories to be rejected")
+	}
+
+	parent, ok := subject.Parent("IAB1-6")
+	if !ok || parent.ID != "IAB1" {
+		t.Errorf("expected IAB1, got %+v", parent)
+	}
+	if _, ok := subject.Parent("IAB1"); ok {
+		t.Errorf("expected no parent")
+	}
+
+	var ids []string
+	for _, c := range subject.Children("IAB1") {
+		ids = append(ids, c.ID)
+	}
+	if exp, got := []string{"IAB1-1", "IAB1-2", "IAB1-3", "IAB1-4", "IAB1-5", "IAB1-6", "IAB1-7"}, ids; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got := subject.Children("IAB1-1"); got != nil {
+		t.Errorf("expected no children, got %v", got)
+	}
+}
+
+func TestTaxonomy_Within(t *testing.T) {
+	subject := Content1()
+	for _, tc := range []struct {
+		id, ancestor string
+		exp          bool
+	}{
+		{"IAB1-1", "IAB1", true},
+		{"IAB1", "IAB1", true},
+		{"IAB1", "IAB1-1", false},
+		{"IAB11-1", "IAB1", false},
+		{"IAB99", "IAB99", false},
+	} {
+		if got := subject.Within(tc.id, tc.ancestor); tc.exp != got {
+			t.Errorf("%s within %s: expected %v, got %v", tc.id, tc.ancestor, tc.exp, got)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	subject, err := Parse("Sample", strings.NewReader(""+
+		"Relational ID System\t\t\tTier 1\tTier 2\tTier 3\n"+
+		"Unique ID\tParent ID\tName\tTier 1\tTier 2\tTier 3\n"+
+		"1\t\tAutomotive\tAutomotive\t\t\n"+
+		"2\t1\tAuto Body Styles\tAutomotive\tAuto Body Styles\t\n"+
+		"3\t2\tConvertible\tAutomotive\tAuto Body Styles\tConvertible\r\n"+
+		"\n"+
+		"X1\t\tSensitive Topics\n"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if exp, got := "Sample", subject.Name; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := []Category{
+		{ID: "1", Name: "Automotive", Tier: 1},
+		{ID: "2", Parent: "1", Name: "Auto Body Styles", Tier: 2},
+		{ID: "3", Parent: "2", Name: "Convertible", Tier: 3},
+		{ID: "X1", Name: "Sensitive Topics", Tier: 1},
+	}, subject.Categories(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if !subject.Within("3", "1") {
+		t.Errorf("expected 3 to be within 1")
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"ID\tParent\tName\n1\t\tAutomotive\n",
+		"Unique ID\tName\n1\tAutomotive\n",
+		"Unique ID\tParent\tName\n1\t\tAutomotive\n1\t\tAutomotive\n",
+		"Unique ID\tParent\tName\n2\t1\tAuto Body Styles\n",
+		"Unique ID\tParent\tName\n1\t2\tA\n2\t1\tB\n",
+	} {
+		if _, err := Parse("Sample", strings.NewReader(data)); !errors.Is(err, ErrInvalidTable) {
+			t.Errorf("expected %v for %q, got %v", ErrInvalidTable, data, err)
+		}
+	}
+}
+
+func mustLookup(t *testing.T, subject *Taxonomy, id string) Category {
+	t.Helper()
+
+	c, ok := subject.Lookup(id)
+	if !ok {
+		t.Fatalf("expected %s to be found", id)
+	}
+	return c
+}
@@ -0,0 +1,240 @@
+// Package uaparser derives device and platform information from legacy
+// User-Agent strings.
+//
+// Parsing is driven by a table of regular expression rules. A default rule set
+// is embedded in the package, custom or updated rules can be loaded with Load.
+package uaparser
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomlightning/openrtb/v3"
+)
+
+//go:embed rules.json
+var defaultRules []byte
+
+// Rule maps a User-Agent pattern to the values it identifies. Name, Version,
+// Make and Model are templates which may reference capture groups of the
+// pattern, e.g. "$1".
+type Rule struct {
+	Pattern  string             `json:"pattern"`
+	Name     string             `json:"name,omitempty"`     // OS or browser name
+	Version  string             `json:"version,omitempty"`  // OS or browser version
+	Versions map[string]string  `json:"versions,omitempty"` // Maps expanded versions to their public names, e.g. "6.1": "7"
+	Make     string             `json:"make,omitempty"`     // Device make
+	Model    string             `json:"model,omitempty"`    // Device model
+	Type     openrtb.DeviceType `json:"type,omitempty"`     // Device type
+
+	re *regexp.Regexp
+}
+
+// Rules is a set of rules, evaluated in order. The first matching rule of
+// each section wins.
+type Rules struct {
+	OS       []*Rule `json:"os"`
+	Devices  []*Rule `json:"devices"`
+	Browsers []*Rule `json:"browsers"`
+}
+
+// Result is the outcome of parsing a User-Agent string.
+type Result struct {
+	OS             string
+	OSVersion      string
+	Make           string
+	Model          string
+	DeviceType     openrtb.DeviceType
+	Browser        string
+	BrowserVersion string
+}
+
+// UserAgent synthesizes a structured user agent from r. Source is always
+// UASourceUserAgent, as values derived from User-Agent strings are less
+// reliable than Client Hints. Returns nil if r is empty.
+func (r *Result) UserAgent() *openrtb.UserAgent {
+	if *r == (Result{}) {
+		return nil
+	}
+
+	ua := &openrtb.UserAgent{
+		Platform: openrtb.BrandVersion{Brand: r.OS, Version: splitVersion(r.OSVersion)},
+		Model:    r.Model,
+		Source:   int(openrtb.UASourceUserAgent),
+	}
+	if r.Browser != "" {
+		ua.Browsers = []openrtb.BrandVersion{{Brand: r.Browser, Version: splitVersion(r.BrowserVersion)}}
+	}
+	if r.DeviceType == openrtb.DeviceTypeMobile || r.DeviceType == openrtb.DeviceTypePhone {
+		ua.Mobile = 1
+	}
+	return ua
+}
+
+// Parser parses User-Agent strings. It is safe for concurrent use.
+type Parser struct {
+	rules Rules
+}
+
+// Load reads rules in JSON format and compiles a Parser.
+func Load(r io.Reader) (*Parser, error) {
+	var rules Rules
+	if err := json.NewDecoder(r).Decode(&rules); err != nil {
+		return nil, err
+	}
+	return New(rules)
+}
+
+// New compiles a Parser from rules. The rules are copied, so they may be
+// reused by the caller.
+func New(rules Rules) (*Parser, error) {
+	var err error
+	p := new(Parser)
+	if p.rules.OS, err = compile(rules.OS); err != nil {
+		return nil, err
+	}
+	if p.rules.Devices, err = compile(rules.Devices); err != nil {
+		return nil, err
+	}
+	if p.rules.Browsers, err = compile(rules.Browsers); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func compile(rules []*Rule) ([]*Rule, error) {
+	res := make([]*Rule, 0, len(rules))
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("uaparser: invalid pattern %q: %w", rule.Pattern, err)
+		}
+
+		cp := *rule
+		cp.re = re
+		res = append(res, &cp)
+	}
+	return res, nil
+}
+
+var (
+	defaultParser     *Parser
+	defaultParserOnce sync.Once
+)
+
+// Default returns a Parser with the embedded rules.
+func Default() *Parser {
+	defaultParserOnce.Do(func() {
+		p, err := Load(bytes.NewReader(defaultRules))
+		if err != nil {
+			panic(err)
+		}
+		defaultParser = p
+	})
+	return defaultParser
+}
+
+// Parse parses ua using the default Parser.
+func Parse(ua string) *Result {
+	return Default().Parse(ua)
+}
+
+// Enrich enriches dev using the default Parser.
+func Enrich(dev *openrtb.Device) bool {
+	return Default().Enrich(dev)
+}
+
+// Parse parses ua.
+func (p *Parser) Parse(ua string) *Result {
+	res := new(Result)
+	if ua == "" {
+		return res
+	}
+
+	if rule, m := match(p.rules.OS, ua); rule != nil {
+		res.OS = expand(rule, rule.Name, ua, m)
+		res.OSVersion = expandVersion(rule, ua, m)
+	}
+	if rule, m := match(p.rules.Devices, ua); rule != nil {
+		res.Make = expand(rule, rule.Make, ua, m)
+		res.Model = expand(rule, rule.Model, ua, m)
+		res.DeviceType = rule.Type
+	}
+	if rule, m := match(p.rules.Browsers, ua); rule != nil {
+		res.Browser = expand(rule, rule.Name, ua, m)
+		res.BrowserVersion = expandVersion(rule, ua, m)
+	}
+	return res
+}
+
+// Enrich parses dev.UA and populates OS, OSVersion, Make, Model and
+// DeviceType, as well as Sua, unless they are already set. Returns true if
+// dev was modified.
+func (p *Parser) Enrich(dev *openrtb.Device) bool {
+	if dev == nil || dev.UA == "" {
+		return false
+	}
+
+	res := p.Parse(dev.UA)
+	changed := false
+	for _, f := range []struct {
+		dst *string
+		val string
+	}{
+		{&dev.OS, res.OS},
+		{&dev.OSVersion, res.OSVersion},
+		{&dev.Make, res.Make},
+		{&dev.Model, res.Model},
+	} {
+		if *f.dst == "" && f.val != "" {
+			*f.dst, changed = f.val, true
+		}
+	}
+	if dev.DeviceType == openrtb.DeviceTypeUnknown && res.DeviceType != openrtb.DeviceTypeUnknown {
+		dev.DeviceType, changed = res.DeviceType, true
+	}
+	if dev.Sua == nil {
+		if ua := res.UserAgent(); ua != nil {
+			dev.Sua, changed = ua, true
+		}
+	}
+	return changed
+}
+
+func match(rules []*Rule, ua string) (*Rule, []int) {
+	for _, rule := range rules {
+		if m := rule.re.FindStringSubmatchIndex(ua); m != nil {
+			return rule, m
+		}
+	}
+	return nil, nil
+}
+
+func expand(rule *Rule, template, ua string, m []int) string {
+	if template == "" {
+		return ""
+	}
+	return strings.TrimSpace(string(rule.re.ExpandString(nil, template, ua, m)))
+}
+
+func expandVersion(rule *Rule, ua string, m []int) string {
+	v := strings.ReplaceAll(expand(rule, rule.Version, ua, m), "_", ".")
+	if name, ok := rule.Versions[v]; ok {
+		return name
+	}
+	return v
+}
+
+func splitVersion(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ".")
+}
@@ -0,0 +1,174 @@
+package uaparser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tomlightning/openrtb/v3"
+
+	. "github.com/tomlightning/openrtb/v3/uaparser"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		ua  string
+		exp Result
+	}{
+		{
+			ua:  "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1.2 Mobile/15E148 Safari/604.1",
+			exp: Result{OS: "iOS", OSVersion: "17.1.2", Make: "Apple", Model: "iPhone", DeviceType: openrtb.DeviceTypePhone, Browser: "Safari", BrowserVersion: "17.1.2"},
+		},
+		{
+			ua:  "Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/118.0.5993.92 Mobile/15E148 Safari/604.1",
+			exp: Result{OS: "iOS", OSVersion: "16.6", Make: "Apple", Model: "iPad", DeviceType: openrtb.DeviceTypeTablet, Browser: "Google Chrome", BrowserVersion: "118.0.5993.92"},
+		},
+		{
+			ua:  "Mozilla/5.0 (Linux; Android 13; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/23.0 Chrome/115.0.0.0 Mobile Safari/537.36",
+			exp: Result{OS: "Android", OSVersion: "13", Make: "Samsung", Model: "SM-S918B", DeviceType: openrtb.DeviceTypePhone, Browser: "Samsung Internet", BrowserVersion: "23.0"},
+		},
+		{
+			ua:  "Mozilla/5.0 (Linux; Android 12; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/117.0.0.0 Safari/537.36",
+			exp: Result{OS: "Android", OSVersion: "12", Make: "Samsung", Model: "SM-X700", DeviceType: openrtb.DeviceTypeTablet, Browser: "Google Chrome", BrowserVersion: "117.0.0.0"},
+		},
+		{
+			ua:  "Mozilla/5.0 (Linux; Android 14; Pixel 8 Pro Build/UD1A.230803.041) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Mobile Safari/537.36",
+			exp: Result{OS: "Android", OSVersion: "14", Make: "Google", Model: "Pixel 8 Pro", DeviceType: openrtb.DeviceTypePhone, Browser: "Google Chrome", BrowserVersion: "118.0.0.0"},
+		},
+		{
+			ua:  "Mozilla/5.0 (Linux; Android 11; Redmi Note 8 Pro) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Mobile Safari/537.36",
+			exp: Result{OS: "Android", OSVersion: "11", Model: "Redmi Note 8 Pro", DeviceType: openrtb.DeviceTypePhone, Browser: "Google Chrome", BrowserVersion: "118.0.0.0"},
+		},
+		{
+			ua:  "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Mobile Safari/537.36",
+			exp: Result{OS: "Android", OSVersion: "10", DeviceType: openrtb.DeviceTypePhone, Browser: "Google Chrome", BrowserVersion: "118.0.0.0"},
+		},
+		{
+			ua:  "Mozilla/5.0 (Linux; Android 9; AFTMM Build/PS7285) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.5359.160 Mobile Safari/537.36",
+			exp: Result{OS: "Android", OSVersion: "9", Make: "Amazon", Model: "AFTMM", DeviceType: openrtb.DeviceTypeSetTopBox, Browser: "Google Chrome", BrowserVersion: "108.0.5359.160"},
+		},
+		{
+			ua:  "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36 Edg/118.0.2088.76",
+			exp: Result{OS: "Windows", OSVersion: "10", DeviceType: openrtb.DeviceTypePC, Browser: "Microsoft Edge", BrowserVersion: "118.0.2088.76"},
+		},
+		{
+			ua:  "Mozilla/5.0 (Windows NT 6.1; WOW64; rv:52.0) Gecko/20100101 Firefox/52.0",
+			exp: Result{OS: "Windows", OSVersion: "7", DeviceType: openrtb.DeviceTypePC, Browser: "Firefox", BrowserVersion: "52.0"},
+		},
+		{
+			ua:  "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15",
+			exp: Result{OS: "macOS", OSVersion: "10.15.7", Make: "Apple", Model: "Macintosh", DeviceType: openrtb.DeviceTypePC, Browser: "Safari", BrowserVersion: "17.1"},
+		},
+		{
+			ua:  "Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/119.0",
+			exp: Result{OS: "Linux", DeviceType: openrtb.DeviceTypePC, Browser: "Firefox", BrowserVersion: "119.0"},
+		},
+		{
+			ua:  "Mozilla/5.0 (X11; CrOS x86_64 15633.69.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.6045.212 Safari/537.36",
+			exp: Result{OS: "Chrome OS", OSVersion: "15633.69.0", DeviceType: openrtb.DeviceTypePC, Browser: "Google Chrome", BrowserVersion: "119.0.6045.212"},
+		},
+		{
+			ua:  "Mozilla/5.0 (SMART-TV; LINUX; Tizen 6.0) AppleWebKit/537.36 (KHTML, like Gecko) 76.0.3809.146/6.0 TV Safari/537.36",
+			exp: Result{OS: "Tizen", OSVersion: "6.0", Make: "Samsung", DeviceType: openrtb.DeviceTypeTV},
+		},
+		{
+			ua:  "Mozilla/5.0 (Web0S; Linux/SmartTV) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/79.0.3945.79 Safari/537.36 WebAppManager",
+			exp: Result{OS: "webOS", Make: "LG", DeviceType: openrtb.DeviceTypeTV, Browser: "Google Chrome", BrowserVersion: "79.0.3945.79"},
+		},
+		{
+			ua:  "Roku/DVP-9.10 (519.10E04111A)",
+			exp: Result{OS: "Roku OS", OSVersion: "9.10", Make: "Roku", DeviceType: openrtb.DeviceTypeSetTopBox},
+		},
+		{
+			ua:  "AppleTV11,1/11.1",
+			exp: Result{Make: "Apple", Model: "Apple TV", DeviceType: openrtb.DeviceTypeSetTopBox},
+		},
+		{
+			ua:  "Mozilla/5.0 (Windows NT 10.0; Win64; x64; Xbox; Xbox Series X) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/48.0.2564.82 Safari/537.36 Edge/20.02",
+			exp: Result{OS: "Windows", OSVersion: "10", Make: "Microsoft", Model: "Xbox", DeviceType: openrtb.DeviceTypeConnected, Browser: "Microsoft Edge", BrowserVersion: "20.02"},
+		},
+		{
+			ua:  "Mozilla/5.0 (PlayStation 5 3.11) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.4 Safari/605.1.15",
+			exp: Result{OS: "PlayStation OS", Make: "Sony", Model: "PlayStation 5", DeviceType: openrtb.DeviceTypeConnected, Browser: "Safari", BrowserVersion: "15.4"},
+		},
+		{
+			ua:  "curl/8.4.0",
+			exp: Result{},
+		},
+	} {
+		if got := Parse(tc.ua); !reflect.DeepEqual(&tc.exp, got) {
+			t.Errorf("%s:\nexpected %+v,\n     got %+v", tc.ua, &tc.exp, got)
+		}
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	subject := &openrtb.Device{
+		UA:   "Mozilla/5.0 (Linux; Android 13; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Mobile Safari/537.36",
+		Make: "samsung",
+	}
+	if !Enrich(subject) {
+		t.Fatal("expected device to be enriched")
+	}
+
+	exp := &openrtb.Device{
+		UA:         subject.UA,
+		Make:       "samsung",
+		Model:      "SM-S918B",
+		OS:         "Android",
+		OSVersion:  "13",
+		DeviceType: openrtb.DeviceTypePhone,
+		Sua: &openrtb.UserAgent{
+			Browsers: []openrtb.BrandVersion{{Brand: "Google Chrome", Version: []string{"118", "0", "0", "0"}}},
+			Platform: openrtb.BrandVersion{Brand: "Android", Version: []string{"13"}},
+			Mobile:   1,
+			Model:    "SM-S918B",
+			Source:   int(openrtb.UASourceUserAgent),
+		},
+	}
+	if !reflect.DeepEqual(exp, subject) {
+		t.Errorf("expected %+v, got %+v", exp, subject)
+	}
+
+	if Enrich(subject) {
+		t.Error("expected no further changes")
+	}
+	if Enrich(&openrtb.Device{}) {
+		t.Error("expected no changes without UA")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	subject, err := Load(strings.NewReader(`{
+		"os": [{"pattern": "MyOS/(\\d+)_(\\d+)", "name": "MyOS", "version": "$1.$2"}],
+		"devices": [{"pattern": "MyBox (\\w+)", "make": "Acme", "model": "Box $1", "type": 7}]
+	}`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := &Result{OS: "MyOS", OSVersion: "3.1", Make: "Acme", Model: "Box Pro", DeviceType: openrtb.DeviceTypeSetTopBox}
+	if got := subject.Parse("MyBox Pro MyOS/3_1"); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	if _, err := Load(strings.NewReader(`{"os": [{"pattern": "("}]}`)); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestNew(t *testing.T) {
+	rules := Rules{OS: []*Rule{{Pattern: "MyOS/(\\d+)", Name: "MyOS", Version: "$1"}}}
+	exp := &Rule{Pattern: "MyOS/(\\d+)", Name: "MyOS", Version: "$1"}
+
+	subject, err := New(rules)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := rules.OS[0]; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected rules to remain unchanged, got %+v", got)
+	}
+	if exp, got := "3", subject.Parse("MyOS/3").OSVersion; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
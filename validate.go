@@ -0,0 +1,14 @@
+package openrtb
+
+import "fmt"
+
+// violations collects the errors reported by ValidateAll.
+type violations []error
+
+// add appends err, prefixed by the path of the offending object.
+func (v *violations) add(path string, err error) {
+	if path != "" {
+		err = fmt.Errorf("%s: %w", path, err)
+	}
+	*v = append(*v, err)
+}
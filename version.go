@@ -0,0 +1,246 @@
+package openrtb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goccy/go-json"
+)
+
+// ErrUnsupportedVersion is returned when encoding for an unknown version of
+// the standard.
+var ErrUnsupportedVersion = errors.New("openrtb: unsupported version")
+
+// Version identifies a version of the OpenRTB standard.
+type Version string
+
+// Version options.
+const (
+	Version24 Version = "2.4"
+	Version25 Version = "2.5"
+	Version26 Version = "2.6"
+)
+
+// MarshalFor encodes the request in the dialect of version v, for partners
+// which do not accept 2.6 yet. The request itself is not modified.
+//
+// For 2.5 and earlier, regs.gdpr, regs.us_privacy, user.consent, user.eids,
+// source.schain and imp.gpid are moved into the ext objects where they were
+// conventionally passed before 2.6, see Normalize for the opposite
+// direction. Video placement types are mapped back to the deprecated
+// placement attribute and fields unknown to the target version are dropped,
+// including API framework values which did not exist yet.
+func (req *BidRequest) MarshalFor(v Version) ([]byte, error) {
+	switch v {
+	case Version26:
+		return json.Marshal(req)
+	case Version25, Version24:
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedVersion, v)
+	}
+
+	dup := req.Clone()
+	if err := downgradeRequest(dup, v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(dup)
+}
+
+// maxAPIFramework returns the last API framework defined by version v.
+func maxAPIFramework(v Version) APIFramework {
+	if v == Version24 {
+		return APIFrameworkMRAID2
+	}
+	return APIFrameworkMRAID3
+}
+
+// plcmtPlacements maps video placement types to the placement types of 2.5.
+var plcmtPlacements = map[VideoPlcmt]VideoPlacement{
+	VideoPlcmtInstream:            VideoPlacementInStream,
+	VideoPlcmtAccompanyingContent: VideoPlacementInterstitial, // slider, floating
+	VideoPlcmtInterstitial:        VideoPlacementInterstitial,
+	VideoPlcmtNoContent:           VideoPlacementInFeed,
+}
+
+func downgradeRequest(req *BidRequest, v Version) (err error) {
+	req.LanguagesB = nil
+	if v == Version24 {
+		req.BlockedSeats = nil
+		req.Languages = nil
+		req.BlockedApps = nil
+	}
+
+	if regs := req.Regulations; regs != nil {
+		if regs.GDPR != 0 {
+			if regs.Ext, err = SetExt(regs.Ext, "gdpr", regs.GDPR); err != nil {
+				return err
+			}
+			regs.GDPR = 0
+		}
+		if regs.USPrivacy != "" {
+			if regs.Ext, err = SetExt(regs.Ext, "us_privacy", regs.USPrivacy); err != nil {
+				return err
+			}
+			regs.USPrivacy = ""
+		}
+	}
+
+	if user := req.User; user != nil {
+		if user.Consent != "" {
+			if user.Ext, err = SetExt(user.Ext, "consent", user.Consent); err != nil {
+				return err
+			}
+			user.Consent = ""
+		}
+		if len(user.EIDs) != 0 {
+			for i := range user.EIDs {
+				eid := &user.EIDs[i]
+				eid.Inserter, eid.Matcher, eid.MatchMethod = "", "", 0
+			}
+			if user.Ext, err = SetExt(user.Ext, "eids", user.EIDs); err != nil {
+				return err
+			}
+			user.EIDs = nil
+		}
+	}
+
+	if src := req.Source; src != nil {
+		if src.SChain != nil {
+			if v == Version24 {
+				req.Ext, err = SetExt(req.Ext, "schain", src.SChain)
+			} else {
+				src.Ext, err = SetExt(src.Ext, "schain", src.SChain)
+			}
+			if err != nil {
+				return err
+			}
+			src.SChain = nil
+		}
+		if v == Version24 {
+			req.Source = nil
+		}
+	}
+
+	if dev := req.Device; dev != nil {
+		dev.Sua = nil
+		dev.LanguageB = ""
+		if v == Version24 {
+			dev.MCCMNC = ""
+			dev.GeoFetch = 0
+		}
+	}
+
+	req.CategoryTaxonomy = 0
+	for _, inv := range []*Inventory{siteInventory(req.Site), appInventory(req.App)} {
+		if inv == nil {
+			continue
+		}
+		inv.CategoryTaxonomy = 0
+		if inv.Content != nil {
+			downgradeContent(inv.Content)
+		}
+	}
+
+	maxAPI := maxAPIFramework(v)
+	for i := range req.Impressions {
+		imp := &req.Impressions[i]
+		if imp.GPID != "" {
+			if imp.Ext, err = SetExt(imp.Ext, "gpid", imp.GPID); err != nil {
+				return err
+			}
+			imp.GPID = ""
+		}
+		if imp.Banner != nil {
+			downgradeBanner(imp.Banner, v)
+		}
+		if imp.Video != nil {
+			downgradeVideo(imp.Video, v)
+		}
+		if imp.Audio != nil {
+			imp.Audio.APIs = filterAPIs(imp.Audio.APIs, maxAPI)
+			for j := range imp.Audio.CompanionAds {
+				downgradeBanner(&imp.Audio.CompanionAds[j], v)
+			}
+		}
+		if imp.Native != nil {
+			imp.Native.APIs = filterAPIs(imp.Native.APIs, maxAPI)
+		}
+	}
+	return nil
+}
+
+func downgradeContent(c *Content) {
+	c.KwArray = nil
+	c.LanguageB = ""
+	c.Network = nil
+	c.Channel = nil
+	c.CategoryTaxonomy = 0
+}
+
+func downgradeBanner(b *Banner, v Version) {
+	b.APIs = filterAPIs(b.APIs, maxAPIFramework(v))
+	if v == Version24 {
+		b.VCM = 0
+
+		// flex formats were introduced in 2.5
+		kept := b.Formats[:0]
+		for _, f := range b.Formats {
+			if f.Width > 0 && f.Height > 0 {
+				f.WidthRatio, f.HeightRatio, f.WidthMin = 0, 0, 0
+				kept = append(kept, f)
+			}
+		}
+		b.Formats = kept
+		if len(b.Formats) == 0 {
+			b.Formats = nil
+		}
+	}
+}
+
+func downgradeVideo(vid *Video, v Version) {
+	if v == Version24 {
+		vid.Placement = VideoPlacementUnknown
+	} else if vid.Placement == VideoPlacementUnknown {
+		vid.Placement = plcmtPlacements[vid.Plcmt]
+	}
+
+	vid.Plcmt = 0
+	vid.RqdDurs = nil
+	vid.PodID = ""
+	vid.PodDuration = 0
+	vid.PodSequence = 0
+	vid.SlotInPod = 0
+	vid.MinCPMPerSecond = 0
+	vid.APIs = filterAPIs(vid.APIs, maxAPIFramework(v))
+	for i := range vid.CompanionAds {
+		downgradeBanner(&vid.CompanionAds[i], v)
+	}
+}
+
+// filterAPIs removes the values above limit from apis, in place.
+func filterAPIs(apis []APIFramework, limit APIFramework) []APIFramework {
+	kept := apis[:0]
+	for _, api := range apis {
+		if api <= limit {
+			kept = append(kept, api)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
+
+func siteInventory(site *Site) *Inventory {
+	if site == nil {
+		return nil
+	}
+	return &site.Inventory
+}
+
+func appInventory(app *App) *Inventory {
+	if app == nil {
+		return nil
+	}
+	return &app.Inventory
+}
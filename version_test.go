@@ -0,0 +1,115 @@
+package openrtb_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	. "github.com/tomlightning/openrtb/v3"
+)
+
+func TestBidRequest_MarshalFor(t *testing.T) {
+	var subject *BidRequest
+	if err := fixture("breq.v26", &subject); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if exp, got := "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA", subject.User.Consent; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := []EID{{
+		Source:      "id5-sync.com",
+		Inserter:    "ssp.example.com",
+		MatchMethod: 2,
+		UIDs:        []UID{{ID: "ID5-ZHMOaW5vZmRh", AType: 1}},
+	}}, subject.User.EIDs; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+	if exp, got := (&SupplyChain{
+		Complete: 1,
+		Version:  "1.0",
+		Nodes:    []SupplyChainNode{{ASI: "exchange.example.com", SID: "1234", HP: 1}},
+	}), subject.Source.SChain; !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+
+	orig := subject.Clone()
+	for _, v := range []Version{Version25, Version24} {
+		data, err := subject.MarshalFor(v)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		golden, err := os.ReadFile(filepath.Join("testdata", "breq.v"+string(v[0])+string(v[2])+".json"))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		var exp, got interface{}
+		if err := json.Unmarshal(golden, &exp); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(exp, got) {
+			t.Errorf("expected %s for %s, got %s", golden, v, data)
+		}
+	}
+	if !reflect.DeepEqual(orig, subject) {
+		t.Errorf("expected request to be unchanged, got %+v", subject)
+	}
+
+	if data, err := subject.MarshalFor(Version26); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	} else if exp, err := json.Marshal(subject); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	} else if string(exp) != string(data) {
+		t.Errorf("expected %s, got %s", exp, data)
+	}
+
+	if _, err := subject.MarshalFor("3.0"); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected %v, got %v", ErrUnsupportedVersion, err)
+	}
+}
+
+func TestBidRequest_MarshalFor_plcmt(t *testing.T) {
+	for plcmt, exp := range map[VideoPlcmt]VideoPlacement{
+		VideoPlcmtInstream:            VideoPlacementInStream,
+		VideoPlcmtAccompanyingContent: VideoPlacementInterstitial,
+		VideoPlcmtInterstitial:        VideoPlacementInterstitial,
+		VideoPlcmtNoContent:           VideoPlacementInFeed,
+	} {
+		subject := &BidRequest{ID: "1", Impressions: []Impression{{ID: "1", Video: &Video{Plcmt: plcmt}}}}
+		data, err := subject.MarshalFor(Version25)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		var got *BidRequest
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if video := got.Impressions[0].Video; video.Placement != exp || video.Plcmt != 0 {
+			t.Errorf("expected placement %v for %v, got %v/%v", exp, plcmt, video.Placement, video.Plcmt)
+		}
+	}
+
+	// explicit placements are kept
+	subject := &BidRequest{ID: "1", Impressions: []Impression{{ID: "1", Video: &Video{Plcmt: VideoPlcmtNoContent, Placement: VideoPlacementInArticle}}}}
+	data, err := subject.MarshalFor(Version25)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var got *BidRequest
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if exp, got := VideoPlacementInArticle, got.Impressions[0].Video.Placement; exp != got {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
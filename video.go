@@ -65,6 +65,18 @@ func (v *Video) Validate() error {
 	return nil
 }
 
+func (v *Video) validateAll(errs *violations, path string) {
+	if len(v.MIMEs) == 0 {
+		errs.add(path, ErrInvalidVideoNoMIMEs)
+	}
+	if v.Linearity == 0 {
+		errs.add(path, ErrInvalidVideoNoLinearity)
+	}
+	if v.Protocol == 0 && len(v.Protocols) == 0 {
+		errs.add(path, ErrInvalidVideoNoProtocols)
+	}
+}
+
 // GetBoxingAllowed returns the boxing-allowed indicator
 func (v *Video) GetBoxingAllowed() int {
 	if v.BoxingAllowed != nil {